@@ -0,0 +1,59 @@
+package folder
+
+import "fmt"
+
+// Move is one move in a batch given to ApplyMoves.
+type Move struct {
+	Name string
+	// an empty Dst moves the folder to the root of its org
+	Dst string
+}
+
+// MoveOutcome reports what a single move in a batch did.
+type MoveOutcome struct {
+	Move Move
+	// the moved folder after the move
+	Folder   Folder
+	OldPaths string
+	// set on the move that failed the batch
+	Err error
+}
+
+// ApplyMoves runs the moves in order against a copy of the tree, so each move
+// sees the ones before it. if every move succeeds the folders are returned
+// and, on a stateful driver, the moves are applied to the driver. if any move
+// fails nothing is applied, and the outcomes stop at the failed move.
+func (d *driver) ApplyMoves(moves []Move) ([]Folder, []MoveOutcome, error) {
+	c := d.clone()
+	outcomes := []MoveOutcome{}
+
+	for i, move := range moves {
+		srcNode, dstNode, err := c.prepareBatchMove(move)
+		if err != nil {
+			outcomes = append(outcomes, MoveOutcome{Move: move, Err: err})
+
+			return nil, outcomes, fmt.Errorf("%w: move %d of %q: %w", ErrBatchMoveFailed, i, move.Name, err)
+		}
+
+		oldPaths := srcNode.Folder.Paths
+		applyMove(srcNode, dstNode)
+
+		outcomes = append(outcomes, MoveOutcome{Move: move, Folder: *srcNode.Folder, OldPaths: oldPaths})
+	}
+
+	if d.stateful {
+		d.adopt(c)
+	}
+
+	return c.allFolders(), outcomes, nil
+}
+
+func (d *driver) prepareBatchMove(move Move) (*FolderNode, *FolderNode, error) {
+	if move.Dst == "" {
+		srcNode, err := d.prepareMoveToRoot(move.Name)
+
+		return srcNode, nil, err
+	}
+
+	return d.prepareMove(move.Name, move.Dst)
+}
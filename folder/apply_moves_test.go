@@ -0,0 +1,180 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_folder_ApplyMoves(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: orgId1, Paths: "delta"},
+		{Name: "echo", OrgId: orgId1, Paths: "delta.echo"},
+		{Name: "foxtrot", OrgId: orgId2, Paths: "foxtrot"},
+	}
+
+	paths := func(folders []folder.Folder) []string {
+		res := []string{}
+		for _, f := range folders {
+			res = append(res, f.Paths)
+		}
+		return res
+	}
+
+	tests := [...]struct {
+		testName string
+		moves    []folder.Move
+		// paths of every folder after the batch, in input order
+		expectPaths []string
+		// OldPaths and new Paths of each outcome
+		expectOutcomes [][2]string
+		expectError    error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName:       "No moves.",
+			moves:          []folder.Move{},
+			expectPaths:    paths(folders),
+			expectOutcomes: [][2]string{},
+		},
+
+		{
+			testName: "Single move.",
+			moves: []folder.Move{
+				{Name: "bravo", Dst: "delta"},
+			},
+			expectPaths: []string{"alpha", "delta.bravo", "delta.bravo.charlie", "delta", "delta.echo", "foxtrot"},
+			expectOutcomes: [][2]string{
+				{"alpha.bravo", "delta.bravo"},
+			},
+		},
+
+		{
+			testName: "Moves build on earlier ones.",
+			moves: []folder.Move{
+				{Name: "bravo", Dst: "delta"},
+				{Name: "charlie", Dst: "echo"},
+				{Name: "echo", Dst: "alpha"},
+			},
+			expectPaths: []string{"alpha", "delta.bravo", "alpha.echo.charlie", "delta", "alpha.echo", "foxtrot"},
+			expectOutcomes: [][2]string{
+				{"alpha.bravo", "delta.bravo"},
+				{"delta.bravo.charlie", "delta.echo.charlie"},
+				{"delta.echo", "alpha.echo"},
+			},
+		},
+
+		{
+			testName: "Move to root.",
+			moves: []folder.Move{
+				{Name: "charlie", Dst: ""},
+				{Name: "alpha", Dst: "charlie"},
+			},
+			expectPaths: []string{"charlie.alpha", "charlie.alpha.bravo", "charlie", "delta", "delta.echo", "foxtrot"},
+			expectOutcomes: [][2]string{
+				{"alpha.bravo.charlie", "charlie"},
+				{"alpha", "charlie.alpha"},
+			},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName: "Earlier move makes a later one invalid.",
+			moves: []folder.Move{
+				{Name: "bravo", Dst: "delta"},
+				{Name: "delta", Dst: "charlie"},
+				{Name: "echo", Dst: "alpha"},
+			},
+			expectOutcomes: [][2]string{
+				{"alpha.bravo", "delta.bravo"},
+				{"", ""},
+			},
+			expectError: folder.ErrMoveToDescendant,
+		},
+
+		{
+			testName: "Source doesnt exist.",
+			moves: []folder.Move{
+				{Name: "bravo", Dst: "delta"},
+				{Name: "x", Dst: "delta"},
+			},
+			expectOutcomes: [][2]string{
+				{"alpha.bravo", "delta.bravo"},
+				{"", ""},
+			},
+			expectError: folder.ErrSourceDoesNotExist,
+		},
+
+		{
+			testName: "Move to a different org.",
+			moves: []folder.Move{
+				{Name: "bravo", Dst: "foxtrot"},
+			},
+			expectOutcomes: [][2]string{
+				{"", ""},
+			},
+			expectError: folder.ErrMoveToDifferentOrg,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		for _, stateful := range []bool{false, true} {
+			name := tc.testName + " Stateless."
+			opts := []folder.DriverOption{}
+			if stateful {
+				name = tc.testName + " Stateful."
+				opts = append(opts, folder.WithStatefulMoves())
+			}
+
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				f, err := folder.NewDriver(folders, opts...)
+				assert.NoError(t, err, "unexpected error")
+
+				result, outcomes, err := f.ApplyMoves(tc.moves)
+
+				got := [][2]string{}
+				for _, outcome := range outcomes {
+					got = append(got, [2]string{outcome.OldPaths, outcome.Folder.Paths})
+				}
+				assert.Equal(t, tc.expectOutcomes, got, "unexpected outcomes")
+
+				expectDriver := paths(folders)
+				if tc.expectError != nil {
+					assert.ErrorIs(t, err, folder.ErrBatchMoveFailed, "expected error")
+					assert.ErrorIs(t, err, tc.expectError, "expected error")
+					assert.ErrorIs(t, outcomes[len(outcomes)-1].Err, tc.expectError, "expected failed outcome")
+				} else {
+					assert.NoError(t, err, "unexpected error")
+					assert.Equal(t, tc.expectPaths, paths(result), "unexpected folders")
+
+					if stateful {
+						expectDriver = tc.expectPaths
+					}
+				}
+
+				all, err := f.GetFoldersByOrgID(orgId1)
+				assert.NoError(t, err, "unexpected error")
+				org2, err := f.GetFoldersByOrgID(orgId2)
+				assert.NoError(t, err, "unexpected error")
+				assert.Equal(t, expectDriver, paths(append(all, org2...)), "unexpected driver state")
+			})
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package folder
+
+import (
+	"slices"
+
+	"github.com/gofrs/uuid"
+)
+
+func (d *driver) LowestCommonAncestor(orgID uuid.UUID, a string, b string) (Folder, error) {
+	nodeA, nodeB, err := d.lookupPair(orgID, a, b)
+	if err != nil {
+		return Folder{}, err
+	}
+
+	lca := lowestCommonAncestor(nodeA, nodeB)
+	if lca == nil || lca.Folder.OrgId != orgID {
+		return Folder{}, ErrNoCommonAncestor
+	}
+
+	return *lca.Folder, nil
+}
+
+func (d *driver) PathBetween(orgID uuid.UUID, a string, b string) ([]Folder, error) {
+	nodeA, nodeB, err := d.lookupPair(orgID, a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	lca := lowestCommonAncestor(nodeA, nodeB)
+	if lca == nil || lca.Folder.OrgId != orgID {
+		return nil, ErrNoCommonAncestor
+	}
+
+	// climb from a up to the ancestor, then from b, and join the two halves.
+	up := []Folder{}
+	for node := nodeA; node != lca; node = node.Parent {
+		if node.Folder.OrgId == orgID {
+			up = append(up, *node.Folder)
+		}
+	}
+
+	down := []Folder{}
+	for node := nodeB; node != lca; node = node.Parent {
+		if node.Folder.OrgId == orgID {
+			down = append(down, *node.Folder)
+		}
+	}
+	slices.Reverse(down)
+
+	res := append(up, *lca.Folder)
+	return append(res, down...), nil
+}
+
+func (d *driver) lookupPair(orgID uuid.UUID, a string, b string) (*FolderNode, *FolderNode, error) {
+	nodeA, err := d.lookupInOrg(orgID, a)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nodeB, err := d.lookupInOrg(orgID, b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nodeA, nodeB, nil
+}
+
+// returns the deepest node that is a or b or an ancestor of both, or nil if
+// they are in different trees.
+func lowestCommonAncestor(a *FolderNode, b *FolderNode) *FolderNode {
+	ancestorsOfA := map[*FolderNode]bool{}
+	for node := a; node != nil; node = node.Parent {
+		ancestorsOfA[node] = true
+	}
+
+	for node := b; node != nil; node = node.Parent {
+		if ancestorsOfA[node] {
+			return node
+		}
+	}
+
+	return nil
+}
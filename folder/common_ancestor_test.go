@@ -0,0 +1,141 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_folder_LowestCommonAncestor(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: orgId1, Paths: "alpha.bravo.delta"},
+		{Name: "echo", OrgId: orgId1, Paths: "alpha.bravo.delta.echo"},
+		{Name: "foxtrot", OrgId: orgId1, Paths: "alpha.foxtrot"},
+		{Name: "golf", OrgId: orgId1, Paths: "golf"},
+		{Name: "hotel", OrgId: orgId2, Paths: "hotel"},
+	}
+
+	tests := [...]struct {
+		testName    string
+		orgID       uuid.UUID
+		a           string
+		b           string
+		expectLCA   string
+		expectPath  []string
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName:   "Cousins.",
+			orgID:      orgId1,
+			a:          "charlie",
+			b:          "echo",
+			expectLCA:  "bravo",
+			expectPath: []string{"charlie", "bravo", "delta", "echo"},
+		},
+
+		{
+			testName:   "Across the root.",
+			orgID:      orgId1,
+			a:          "echo",
+			b:          "foxtrot",
+			expectLCA:  "alpha",
+			expectPath: []string{"echo", "delta", "bravo", "alpha", "foxtrot"},
+		},
+
+		{
+			testName:   "Ancestor and descendant.",
+			orgID:      orgId1,
+			a:          "alpha",
+			b:          "charlie",
+			expectLCA:  "alpha",
+			expectPath: []string{"alpha", "bravo", "charlie"},
+		},
+
+		{
+			testName:   "Descendant and ancestor.",
+			orgID:      orgId1,
+			a:          "charlie",
+			b:          "alpha",
+			expectLCA:  "alpha",
+			expectPath: []string{"charlie", "bravo", "alpha"},
+		},
+
+		{
+			testName:   "Same folder.",
+			orgID:      orgId1,
+			a:          "delta",
+			b:          "delta",
+			expectLCA:  "delta",
+			expectPath: []string{"delta"},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Different roots.",
+			orgID:       orgId1,
+			a:           "charlie",
+			b:           "golf",
+			expectError: folder.ErrNoCommonAncestor,
+		},
+
+		{
+			testName:    "Different orgs.",
+			orgID:       orgId1,
+			a:           "charlie",
+			b:           "hotel",
+			expectError: folder.ErrFolderDoesNotExistInOrg,
+		},
+
+		{
+			testName:    "Folder doesnt exist.",
+			orgID:       orgId1,
+			a:           "x",
+			b:           "charlie",
+			expectError: folder.ErrFolderDoesNotExist,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			lca, lcaErr := f.LowestCommonAncestor(tc.orgID, tc.a, tc.b)
+			path, pathErr := f.PathBetween(tc.orgID, tc.a, tc.b)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, lcaErr, tc.expectError, "expected error")
+				assert.ErrorIs(t, pathErr, tc.expectError, "expected error")
+				return
+			}
+
+			assert.NoError(t, lcaErr, "unexpected error")
+			assert.Equal(t, tc.expectLCA, lca.Name, "unexpected ancestor")
+
+			assert.NoError(t, pathErr, "unexpected error")
+			names := []string{}
+			for _, f := range path {
+				names = append(names, f.Name)
+			}
+			assert.Equal(t, tc.expectPath, names, "unexpected path")
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package folder
+
+import (
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/lucasepe/codename"
+)
+
+// how many names a Namer gets to offer before a copy is given up on
+const maxNameAttempts = 100
+
+// Namer proposes a name for the copy of the folder called name.
+// attempt starts at 0 and increases each time the previous proposal was taken or invalid.
+type Namer func(name string, attempt int) string
+
+// SuffixNamer names copies "<name>-copy", then "<name>-copy-2", "<name>-copy-3" and so on.
+func SuffixNamer(name string, attempt int) string {
+	if attempt == 0 {
+		return name + "-copy"
+	}
+
+	return fmt.Sprintf("%s-copy-%d", name, attempt+1)
+}
+
+// NewCodenameNamer names copies with random codenames, like GenerateData does.
+func NewCodenameNamer() Namer {
+	rng, _ := codename.DefaultRNG()
+
+	return func(string, int) string {
+		return codename.Generate(rng, 0)
+	}
+}
+
+// FolderCopy pairs a copied folder with the folder it was copied from.
+type FolderCopy struct {
+	Original Folder
+	Copy     Folder
+}
+
+func (d *driver) CopyFolder(
+	name string,
+	dst string,
+	namer Namer,
+) ([]FolderCopy, error) {
+	if name == "" || dst == "" {
+		return nil, ErrInvalidArguments
+	}
+
+	if namer == nil {
+		namer = SuffixNamer
+	}
+
+	srcNode, err := d.lookup(name)
+	if err == ErrFolderDoesNotExist {
+		return nil, ErrSourceDoesNotExist
+	} else if err != nil {
+		return nil, err
+	}
+
+	dstNode, err := d.lookupInOrg(srcNode.Folder.OrgId, dst)
+	if err == ErrFolderDoesNotExist {
+		return nil, ErrDestDoesNotExist
+	} else if err == ErrFolderDoesNotExistInOrg {
+		return nil, ErrCopyToDifferentOrg
+	} else if err != nil {
+		return nil, err
+	}
+
+	// snapshot the subtree first, the destination may be inside it.
+	originals := []*FolderNode{}
+	collectInOrg(&originals, srcNode, srcNode.Folder.OrgId)
+
+	// pick every name up front so a failure leaves the tree untouched.
+	taken := map[string]bool{}
+	newNames := make([]string, len(originals))
+	for i, original := range originals {
+		newName, err := d.generateName(original.Folder.Name, namer, taken)
+		if err != nil {
+			return nil, err
+		}
+
+		taken[newName] = true
+		newNames[i] = newName
+	}
+
+	res := []FolderCopy{}
+	copies := map[*FolderNode]*FolderNode{srcNode.Parent: dstNode}
+
+	for i, original := range originals {
+		parent := copies[original.Parent]
+
+		node := d.addNode(Folder{
+			ID:    uuid.Must(uuid.NewV4()),
+			Name:  newNames[i],
+			OrgId: original.Folder.OrgId,
+			Paths: parent.Folder.Paths + "." + newNames[i],
+		})
+		attachNode(node, parent)
+		copies[original] = node
+
+		res = append(res, FolderCopy{Original: *original.Folder, Copy: *node.Folder})
+	}
+
+	return res, nil
+}
+
+// appends node and its descendants in orgID to res, parents before children.
+// folders of other orgs are skipped along with everything below them, since
+// copies are only ever made in one org and would have no parent to attach to.
+func collectInOrg(res *[]*FolderNode, node *FolderNode, orgID uuid.UUID) {
+	if node.Folder.OrgId != orgID {
+		return
+	}
+
+	*res = append(*res, node)
+	for _, child := range node.Children {
+		collectInOrg(res, child, orgID)
+	}
+}
+
+// asks namer for names until it offers a valid one that isn't in use.
+func (d *driver) generateName(name string, namer Namer, taken map[string]bool) (string, error) {
+	for attempt := 0; attempt < maxNameAttempts; attempt++ {
+		newName := namer(name, attempt)
+
+		if validateFolderName(newName) != nil || taken[newName] {
+			continue
+		}
+
+		// copies get names unused anywhere, so they can be looked up by name.
+		if len(d.nameToNodes[newName]) == 0 {
+			return newName, nil
+		}
+	}
+
+	return "", ErrNameGenerationFailed
+}
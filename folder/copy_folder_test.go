@@ -0,0 +1,226 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_folder_CopyFolder(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+		{Name: "bravo-copy", OrgId: orgId1, Paths: "bravo-copy"},
+		{Name: "golf", OrgId: orgId1, Paths: "golf"},
+		{Name: "foxtrot", OrgId: orgId2, Paths: "foxtrot"},
+	}
+
+	tests := [...]struct {
+		testName    string
+		src         string
+		dst         string
+		namer       folder.Namer
+		expect      []folder.FolderCopy
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName: "Copy a subtree, skipping names already in use.",
+			src:      "bravo",
+			dst:      "golf",
+			namer:    folder.SuffixNamer,
+			expect: []folder.FolderCopy{
+				{
+					Original: folder.Folder{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+					Copy:     folder.Folder{Name: "bravo-copy-2", OrgId: orgId1, Paths: "golf.bravo-copy-2"},
+				},
+				{
+					Original: folder.Folder{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+					Copy:     folder.Folder{Name: "charlie-copy", OrgId: orgId1, Paths: "golf.bravo-copy-2.charlie-copy"},
+				},
+			},
+		},
+
+		{
+			testName: "Defaults to the suffix namer.",
+			src:      "charlie",
+			dst:      "alpha",
+			namer:    nil,
+			expect: []folder.FolderCopy{
+				{
+					Original: folder.Folder{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+					Copy:     folder.Folder{Name: "charlie-copy", OrgId: orgId1, Paths: "alpha.charlie-copy"},
+				},
+			},
+		},
+
+		{
+			testName: "Copy a folder into its own subtree.",
+			src:      "alpha",
+			dst:      "bravo",
+			namer:    folder.SuffixNamer,
+			expect: []folder.FolderCopy{
+				{
+					Original: folder.Folder{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+					Copy:     folder.Folder{Name: "alpha-copy", OrgId: orgId1, Paths: "alpha.bravo.alpha-copy"},
+				},
+				{
+					Original: folder.Folder{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+					Copy:     folder.Folder{Name: "bravo-copy-2", OrgId: orgId1, Paths: "alpha.bravo.alpha-copy.bravo-copy-2"},
+				},
+				{
+					Original: folder.Folder{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+					Copy:     folder.Folder{Name: "charlie-copy", OrgId: orgId1, Paths: "alpha.bravo.alpha-copy.bravo-copy-2.charlie-copy"},
+				},
+			},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Source doesnt exist.",
+			src:         "x",
+			dst:         "golf",
+			expectError: folder.ErrSourceDoesNotExist,
+		},
+
+		{
+			testName:    "Destination doesnt exist.",
+			src:         "bravo",
+			dst:         "x",
+			expectError: folder.ErrDestDoesNotExist,
+		},
+
+		{
+			testName:    "Empty destination.",
+			src:         "bravo",
+			dst:         "",
+			expectError: folder.ErrInvalidArguments,
+		},
+
+		{
+			testName:    "Can't copy across organisations.",
+			src:         "bravo",
+			dst:         "foxtrot",
+			expectError: folder.ErrCopyToDifferentOrg,
+		},
+
+		{
+			testName: "Namer never offers a free name.",
+			src:      "bravo",
+			dst:      "golf",
+			namer: func(string, int) string {
+				return "golf"
+			},
+			expectError: folder.ErrNameGenerationFailed,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			result, err := f.CopyFolder(tc.src, tc.dst, tc.namer)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+
+				after, err := f.GetFoldersByOrgID(orgId1)
+				assert.NoError(t, err, "unexpected error")
+				assert.Len(t, after, 5, "failed copy changed the tree")
+				return
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+
+			// copies get fresh IDs, which can't be known up front.
+			for i := 0; i < len(result) && i < len(tc.expect); i++ {
+				assert.NotEqual(t, uuid.Nil, result[i].Copy.ID, "copy should get an ID")
+				tc.expect[i].Copy.ID = result[i].Copy.ID
+			}
+			assert.Equal(t, tc.expect, result, "unexpected result")
+
+			children, err := f.GetAllChildFolders(orgId1, tc.dst)
+			assert.NoError(t, err, "unexpected error")
+			for _, c := range tc.expect {
+				assert.Contains(t, children, c.Copy, "copy isnt linked under the destination")
+			}
+		})
+	}
+}
+
+func Test_folder_CopyFolder_Codename(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	f, err := folder.NewDriver([]folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "golf", OrgId: orgId1, Paths: "golf"},
+	})
+	assert.NoError(t, err, "unexpected error")
+
+	result, err := f.CopyFolder("alpha", "golf", folder.NewCodenameNamer())
+	assert.NoError(t, err, "unexpected error")
+	assert.Len(t, result, 2, "unexpected result")
+
+	root, child := result[0].Copy, result[1].Copy
+	assert.NotEqual(t, root.Name, child.Name, "copies should have unique names")
+	assert.Equal(t, "golf."+root.Name, root.Paths, "unexpected path")
+	assert.Equal(t, root.Paths+"."+child.Name, child.Paths, "unexpected path")
+}
+
+func Test_folder_CopyFolder_CrossOrg(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	f, err := folder.NewDriver([]folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "hotel", OrgId: orgId2, Paths: "alpha.hotel"},
+		{Name: "india", OrgId: orgId1, Paths: "alpha.hotel.india"},
+		{Name: "golf", OrgId: orgId1, Paths: "golf"},
+	})
+	assert.NoError(t, err, "unexpected error")
+
+	result, err := f.CopyFolder("alpha", "golf", folder.SuffixNamer)
+	assert.NoError(t, err, "unexpected error")
+
+	for i := range result {
+		result[i].Copy.ID = uuid.Nil
+	}
+	assert.Equal(t, []folder.FolderCopy{
+		{
+			Original: folder.Folder{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+			Copy:     folder.Folder{Name: "alpha-copy", OrgId: orgId1, Paths: "golf.alpha-copy"},
+		},
+		{
+			Original: folder.Folder{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+			Copy:     folder.Folder{Name: "bravo-copy", OrgId: orgId1, Paths: "golf.alpha-copy.bravo-copy"},
+		},
+	}, result, "other org's folders should be skipped")
+
+	after, err := f.GetFoldersByOrgID(orgId2)
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, []folder.Folder{
+		{Name: "hotel", OrgId: orgId2, Paths: "alpha.hotel"},
+	}, after, "other org changed")
+}
@@ -0,0 +1,77 @@
+package folder
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+func (d *driver) CreateFolder(
+	orgID uuid.UUID,
+	parentName string,
+	name string,
+) (Folder, error) {
+	if err := validateFolderName(name); err != nil {
+		return Folder{}, err
+	}
+
+	parentNode, err := d.lookupInOrg(orgID, parentName)
+	if err != nil {
+		return Folder{}, err
+	}
+
+	path := parentNode.Folder.Paths + "." + name
+
+	if d.folderAt(orgID, path) != nil {
+		return Folder{}, ErrFolderAlreadyExists
+	}
+
+	node := d.addNode(Folder{
+		ID:    uuid.Must(uuid.NewV4()),
+		Name:  name,
+		OrgId: orgID,
+		Paths: path,
+	})
+	attachNode(node, parentNode)
+
+	return *node.Folder, nil
+}
+
+func (d *driver) CreateRootFolder(orgID uuid.UUID, name string) (Folder, error) {
+	if err := validateFolderName(name); err != nil {
+		return Folder{}, err
+	}
+
+	if d.folderAt(orgID, name) != nil {
+		return Folder{}, ErrFolderAlreadyExists
+	}
+
+	node := d.addNode(Folder{
+		ID:    uuid.Must(uuid.NewV4()),
+		Name:  name,
+		OrgId: orgID,
+		Paths: name,
+	})
+
+	return *node.Folder, nil
+}
+
+// checks that name can be used as a single ltree label.
+func validateFolderName(name string) error {
+	if name == "" {
+		return ErrInvalidFolderName
+	}
+
+	for _, r := range name {
+		if !isLabelRune(r) {
+			return ErrInvalidFolderName
+		}
+	}
+
+	return nil
+}
+
+func isLabelRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') ||
+		(r >= 'A' && r <= 'Z') ||
+		(r >= '0' && r <= '9') ||
+		r == '_' || r == '-'
+}
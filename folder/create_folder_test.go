@@ -0,0 +1,178 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_folder_CreateFolder(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "foxtrot", OrgId: orgId2, Paths: "foxtrot"},
+	}
+
+	tests := [...]struct {
+		testName    string
+		orgID       uuid.UUID
+		parent      string
+		name        string
+		expect      folder.Folder
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName: "Create under a root folder.",
+			orgID:    orgId1,
+			parent:   "alpha",
+			name:     "charlie",
+			expect:   folder.Folder{Name: "charlie", OrgId: orgId1, Paths: "alpha.charlie"},
+		},
+
+		{
+			testName: "Create under a nested folder.",
+			orgID:    orgId1,
+			parent:   "bravo",
+			name:     "charlie_2-x",
+			expect:   folder.Folder{Name: "charlie_2-x", OrgId: orgId1, Paths: "alpha.bravo.charlie_2-x"},
+		},
+
+		{
+			testName: "Name reused from another org.",
+			orgID:    orgId1,
+			parent:   "alpha",
+			name:     "foxtrot",
+			expect:   folder.Folder{Name: "foxtrot", OrgId: orgId1, Paths: "alpha.foxtrot"},
+		},
+
+		{
+			testName: "Name reused under another parent.",
+			orgID:    orgId1,
+			parent:   "bravo",
+			name:     "alpha",
+			expect:   folder.Folder{Name: "alpha", OrgId: orgId1, Paths: "alpha.bravo.alpha"},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Empty name.",
+			orgID:       orgId1,
+			parent:      "alpha",
+			name:        "",
+			expectError: folder.ErrInvalidFolderName,
+		},
+
+		{
+			testName:    "Name containing a path separator.",
+			orgID:       orgId1,
+			parent:      "alpha",
+			name:        "charlie.delta",
+			expectError: folder.ErrInvalidFolderName,
+		},
+
+		{
+			testName:    "Name containing whitespace.",
+			orgID:       orgId1,
+			parent:      "alpha",
+			name:        "char lie",
+			expectError: folder.ErrInvalidFolderName,
+		},
+
+		{
+			testName:    "Duplicate name among siblings.",
+			orgID:       orgId1,
+			parent:      "alpha",
+			name:        "bravo",
+			expectError: folder.ErrFolderAlreadyExists,
+		},
+
+		{
+			testName:    "Parent doesnt exist.",
+			orgID:       orgId1,
+			parent:      "x",
+			name:        "charlie",
+			expectError: folder.ErrFolderDoesNotExist,
+		},
+
+		{
+			testName:    "Parent in a different org.",
+			orgID:       orgId1,
+			parent:      "foxtrot",
+			name:        "charlie",
+			expectError: folder.ErrFolderDoesNotExistInOrg,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			result, err := f.CreateFolder(tc.orgID, tc.parent, tc.name)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+				return
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+
+			assert.NotEqual(t, uuid.Nil, result.ID, "created folder should get an ID")
+			tc.expect.ID = result.ID
+			assert.Equal(t, tc.expect, result, "unexpected result")
+
+			children, err := f.GetAllChildFolders(tc.orgID, tc.parent)
+			assert.NoError(t, err, "unexpected error")
+			assert.Contains(t, children, tc.expect, "created folder isnt linked to its parent")
+		})
+	}
+}
+
+func Test_folder_CreateRootFolder(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	f, err := folder.NewDriver([]folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+	})
+	assert.NoError(t, err, "unexpected error")
+
+	golf, err := f.CreateRootFolder(orgId1, "golf")
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, folder.Folder{ID: golf.ID, Name: "golf", OrgId: orgId1, Paths: "golf"}, golf, "unexpected result")
+	assert.NotEqual(t, uuid.Nil, golf.ID, "created folder should get an ID")
+
+	_, err = f.CreateRootFolder(orgId1, "golf")
+	assert.ErrorIs(t, err, folder.ErrFolderAlreadyExists)
+
+	_, err = f.CreateRootFolder(orgId1, "")
+	assert.ErrorIs(t, err, folder.ErrInvalidFolderName)
+
+	// new folders can be built on and moved like any other.
+	hotel, err := f.CreateFolder(orgId1, "golf", "hotel")
+	assert.NoError(t, err, "unexpected error")
+
+	result, err := f.MoveFolder("golf", "alpha")
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{ID: golf.ID, Name: "golf", OrgId: orgId1, Paths: "alpha.golf"},
+		{ID: hotel.ID, Name: "hotel", OrgId: orgId1, Paths: "alpha.golf.hotel"},
+	}, result, "unexpected result")
+}
@@ -0,0 +1,159 @@
+package folder
+
+import (
+	"slices"
+
+	"github.com/gofrs/uuid"
+)
+
+// DeleteStrategy decides what happens to the children of a deleted folder.
+type DeleteStrategy int
+
+const (
+	// DeleteRefuseIfNonEmpty only deletes folders without children.
+	DeleteRefuseIfNonEmpty DeleteStrategy = iota
+	// DeleteCascade deletes the folder along with all of its descendants.
+	DeleteCascade
+	// DeleteReparent moves the children up to the deleted folder's parent,
+	// or makes them roots if the deleted folder was a root.
+	DeleteReparent
+)
+
+// DeleteResult reports every folder touched by a delete.
+type DeleteResult struct {
+	Removed   []Folder
+	Rewritten []PathChange
+}
+
+func (d *driver) DeleteFolder(
+	orgID uuid.UUID,
+	name string,
+	strategy DeleteStrategy,
+) (DeleteResult, error) {
+	node, err := d.lookupInOrg(orgID, name)
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	switch strategy {
+	case DeleteRefuseIfNonEmpty:
+		// only the org's own folders count, as in GetAllChildFolders, so
+		// the error says nothing about other orgs' folders.
+		descendants := []Folder{}
+		getDescendants(&descendants, node, orgID, unlimitedDepth)
+		if len(descendants) > 0 {
+			return DeleteResult{}, ErrFolderNotEmpty
+		}
+
+		return d.deleteSubtree(node, orgID), nil
+
+	case DeleteCascade:
+		return d.deleteSubtree(node, orgID), nil
+
+	case DeleteReparent:
+		if err := d.checkReparent(node); err != nil {
+			return DeleteResult{}, err
+		}
+
+		return d.deleteAndReparent(node, orgID), nil
+	}
+
+	return DeleteResult{}, ErrUnknownDeleteStrategy
+}
+
+// removes node and the folders of orgID below it. folders of other orgs
+// directly below a removed folder are left in place without a parent, as
+// BuildFolderTree leaves folders whose parent is missing.
+func (d *driver) deleteSubtree(node *FolderNode, orgID uuid.UUID) DeleteResult {
+	res := DeleteResult{Removed: []Folder{}, Rewritten: []PathChange{}}
+	removed := []*FolderNode{}
+	isRemoved := map[*FolderNode]bool{}
+
+	walkSubtree(node, func(n *FolderNode) {
+		if n.Folder.OrgId != orgID {
+			return
+		}
+
+		res.Removed = append(res.Removed, *n.Folder)
+		removed = append(removed, n)
+		isRemoved[n] = true
+	})
+
+	for _, n := range removed {
+		if !isRemoved[n.Parent] {
+			detachNode(n)
+		}
+
+		for _, child := range n.Children {
+			if !isRemoved[child] {
+				child.Parent = nil
+			}
+		}
+	}
+
+	d.removeNodes(removed)
+
+	return res
+}
+
+// checks that none of node's children clash with a sibling once moved up a level.
+func (d *driver) checkReparent(node *FolderNode) error {
+	newParentPath := parentPath(node.Folder.Paths)
+
+	for _, child := range node.Children {
+		occupant := d.folderAt(child.Folder.OrgId, childPath(newParentPath, child.Folder.Name))
+		if occupant != nil && occupant != node {
+			return ErrFolderAlreadyExists
+		}
+	}
+
+	return nil
+}
+
+// removes node, splicing its children into its place under node's parent.
+// every moved folder has its path rewritten, but only those of orgID are reported.
+func (d *driver) deleteAndReparent(node *FolderNode, orgID uuid.UUID) DeleteResult {
+	res := DeleteResult{Removed: []Folder{*node.Folder}, Rewritten: []PathChange{}}
+
+	parent := node.Parent
+	newParentPath := parentPath(node.Folder.Paths)
+
+	children := node.Children
+	// children only keep positions if the parent's children are ordered.
+	ordered := parent != nil && isOrdered(parent.Children)
+	if parent != nil {
+		// keep the children where the deleted folder used to be.
+		i := slices.Index(parent.Children, node)
+		parent.Children = slices.Concat(parent.Children[:i], children, parent.Children[i+1:])
+
+		if ordered {
+			numberChildren(parent)
+		}
+	}
+
+	for _, child := range children {
+		if !ordered {
+			child.Folder.Position = 0
+		}
+
+		oldPaths := map[*FolderNode]string{}
+		walkSubtree(child, func(n *FolderNode) {
+			oldPaths[n] = n.Folder.Paths
+		})
+
+		rebasePaths(child, replacePathPrefix(child.Folder.Paths, node.Folder.Paths, newParentPath))
+		child.Parent = parent
+
+		walkSubtree(child, func(n *FolderNode) {
+			if n.Folder.OrgId == orgID {
+				res.Rewritten = append(res.Rewritten, PathChange{Folder: *n.Folder, OldPaths: oldPaths[n]})
+			}
+		})
+	}
+
+	node.Parent = nil
+	node.Children = nil
+	d.removeNodes([]*FolderNode{node})
+
+	return res
+}
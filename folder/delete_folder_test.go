@@ -0,0 +1,344 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_folder_DeleteFolder(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: orgId1, Paths: "alpha.bravo.delta"},
+		{Name: "echo", OrgId: orgId1, Paths: "alpha.echo"},
+		{Name: "foxtrot", OrgId: orgId2, Paths: "foxtrot"},
+	}
+
+	tests := [...]struct {
+		testName    string
+		orgID       uuid.UUID
+		folderName  string
+		strategy    folder.DeleteStrategy
+		expect      folder.DeleteResult
+		expectAfter []folder.Folder
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName:   "Refuse strategy deletes a leaf.",
+			orgID:      orgId1,
+			folderName: "echo",
+			strategy:   folder.DeleteRefuseIfNonEmpty,
+			expect: folder.DeleteResult{
+				Removed:   []folder.Folder{{Name: "echo", OrgId: orgId1, Paths: "alpha.echo"}},
+				Rewritten: []folder.PathChange{},
+			},
+			expectAfter: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+				{Name: "delta", OrgId: orgId1, Paths: "alpha.bravo.delta"},
+			},
+		},
+
+		{
+			testName:   "Cascade removes the whole subtree.",
+			orgID:      orgId1,
+			folderName: "bravo",
+			strategy:   folder.DeleteCascade,
+			expect: folder.DeleteResult{
+				Removed: []folder.Folder{
+					{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+					{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+					{Name: "delta", OrgId: orgId1, Paths: "alpha.bravo.delta"},
+				},
+				Rewritten: []folder.PathChange{},
+			},
+			expectAfter: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "echo", OrgId: orgId1, Paths: "alpha.echo"},
+			},
+		},
+
+		{
+			testName:   "Reparent splices children into the parent.",
+			orgID:      orgId1,
+			folderName: "bravo",
+			strategy:   folder.DeleteReparent,
+			expect: folder.DeleteResult{
+				Removed: []folder.Folder{{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"}},
+				Rewritten: []folder.PathChange{
+					{Folder: folder.Folder{Name: "charlie", OrgId: orgId1, Paths: "alpha.charlie"}, OldPaths: "alpha.bravo.charlie"},
+					{Folder: folder.Folder{Name: "delta", OrgId: orgId1, Paths: "alpha.delta"}, OldPaths: "alpha.bravo.delta"},
+				},
+			},
+			expectAfter: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.charlie"},
+				{Name: "delta", OrgId: orgId1, Paths: "alpha.delta"},
+				{Name: "echo", OrgId: orgId1, Paths: "alpha.echo"},
+			},
+		},
+
+		{
+			testName:   "Reparent of a root makes its children roots.",
+			orgID:      orgId1,
+			folderName: "alpha",
+			strategy:   folder.DeleteReparent,
+			expect: folder.DeleteResult{
+				Removed: []folder.Folder{{Name: "alpha", OrgId: orgId1, Paths: "alpha"}},
+				Rewritten: []folder.PathChange{
+					{Folder: folder.Folder{Name: "bravo", OrgId: orgId1, Paths: "bravo"}, OldPaths: "alpha.bravo"},
+					{Folder: folder.Folder{Name: "charlie", OrgId: orgId1, Paths: "bravo.charlie"}, OldPaths: "alpha.bravo.charlie"},
+					{Folder: folder.Folder{Name: "delta", OrgId: orgId1, Paths: "bravo.delta"}, OldPaths: "alpha.bravo.delta"},
+					{Folder: folder.Folder{Name: "echo", OrgId: orgId1, Paths: "echo"}, OldPaths: "alpha.echo"},
+				},
+			},
+			expectAfter: []folder.Folder{
+				{Name: "bravo", OrgId: orgId1, Paths: "bravo"},
+				{Name: "charlie", OrgId: orgId1, Paths: "bravo.charlie"},
+				{Name: "delta", OrgId: orgId1, Paths: "bravo.delta"},
+				{Name: "echo", OrgId: orgId1, Paths: "echo"},
+			},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Refuse strategy with children.",
+			orgID:       orgId1,
+			folderName:  "bravo",
+			strategy:    folder.DeleteRefuseIfNonEmpty,
+			expectError: folder.ErrFolderNotEmpty,
+		},
+
+		{
+			testName:    "Folder doesnt exist.",
+			orgID:       orgId1,
+			folderName:  "x",
+			strategy:    folder.DeleteCascade,
+			expectError: folder.ErrFolderDoesNotExist,
+		},
+
+		{
+			testName:    "Folder in a different org.",
+			orgID:       orgId1,
+			folderName:  "foxtrot",
+			strategy:    folder.DeleteCascade,
+			expectError: folder.ErrFolderDoesNotExistInOrg,
+		},
+
+		{
+			testName:    "Unknown strategy.",
+			orgID:       orgId1,
+			folderName:  "echo",
+			strategy:    folder.DeleteStrategy(42),
+			expectError: folder.ErrUnknownDeleteStrategy,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			result, err := f.DeleteFolder(tc.orgID, tc.folderName, tc.strategy)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+				return
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+
+			assert.Equal(t, tc.expect, result, "unexpected result")
+
+			after, err := f.GetFoldersByOrgID(tc.orgID)
+			assert.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectAfter, after, "unexpected folders after delete")
+		})
+	}
+}
+
+func Test_folder_DeleteFolder_ReparentKeepsOrder(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	f, err := folder.NewDriver([]folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.charlie"},
+		{Name: "delta", OrgId: orgId1, Paths: "alpha.charlie.delta"},
+		{Name: "echo", OrgId: orgId1, Paths: "alpha.echo"},
+	})
+	assert.NoError(t, err, "unexpected error")
+
+	_, err = f.DeleteFolder(orgId1, "charlie", folder.DeleteReparent)
+	assert.NoError(t, err, "unexpected error")
+
+	children, err := f.GetAllChildFolders(orgId1, "alpha")
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, []folder.Folder{
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "delta", OrgId: orgId1, Paths: "alpha.delta"},
+		{Name: "echo", OrgId: orgId1, Paths: "alpha.echo"},
+	}, children, "unexpected result")
+
+	_, err = f.GetAllChildFolders(orgId1, "charlie")
+	assert.ErrorIs(t, err, folder.ErrFolderDoesNotExist)
+}
+
+func Test_folder_DeleteFolder_ReparentNameClash(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	f, err := folder.NewDriver([]folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "reports", OrgId: orgId1, Paths: "alpha.reports"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "reports", OrgId: orgId1, Paths: "alpha.bravo.reports"},
+	})
+	assert.NoError(t, err, "unexpected error")
+
+	_, err = f.DeleteFolder(orgId1, "bravo", folder.DeleteReparent)
+	assert.ErrorIs(t, err, folder.ErrFolderAlreadyExists)
+
+	// nothing was changed by the refused delete.
+	result, err := f.GetAllChildFolders(orgId1, "bravo")
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, []folder.Folder{
+		{Name: "reports", OrgId: orgId1, Paths: "alpha.bravo.reports"},
+	}, result, "unexpected result")
+}
+
+func Test_folder_DeleteFolder_CrossOrg(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "golf", OrgId: orgId1, Paths: "golf"},
+		{Name: "hotel", OrgId: orgId2, Paths: "golf.hotel"},
+		{Name: "india", OrgId: orgId2, Paths: "golf.hotel.india"},
+		{Name: "juliet", OrgId: orgId1, Paths: "golf.hotel.juliet"},
+		{Name: "kilo", OrgId: orgId1, Paths: "golf.kilo"},
+		{Name: "lima", OrgId: orgId1, Paths: "lima"},
+		{Name: "mike", OrgId: orgId2, Paths: "lima.mike"},
+	}
+
+	tests := [...]struct {
+		testName    string
+		folderName  string
+		strategy    folder.DeleteStrategy
+		expect      folder.DeleteResult
+		expectOrg2  []folder.Folder
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName:   "Cascade leaves other orgs alone.",
+			folderName: "golf",
+			strategy:   folder.DeleteCascade,
+			expect: folder.DeleteResult{
+				Removed: []folder.Folder{
+					{Name: "golf", OrgId: orgId1, Paths: "golf"},
+					{Name: "juliet", OrgId: orgId1, Paths: "golf.hotel.juliet"},
+					{Name: "kilo", OrgId: orgId1, Paths: "golf.kilo"},
+				},
+				Rewritten: []folder.PathChange{},
+			},
+			expectOrg2: []folder.Folder{
+				{Name: "hotel", OrgId: orgId2, Paths: "golf.hotel"},
+				{Name: "india", OrgId: orgId2, Paths: "golf.hotel.india"},
+				{Name: "mike", OrgId: orgId2, Paths: "lima.mike"},
+			},
+		},
+
+		{
+			testName:   "Refuse strategy ignores other orgs' children.",
+			folderName: "lima",
+			strategy:   folder.DeleteRefuseIfNonEmpty,
+			expect: folder.DeleteResult{
+				Removed:   []folder.Folder{{Name: "lima", OrgId: orgId1, Paths: "lima"}},
+				Rewritten: []folder.PathChange{},
+			},
+			expectOrg2: []folder.Folder{
+				{Name: "hotel", OrgId: orgId2, Paths: "golf.hotel"},
+				{Name: "india", OrgId: orgId2, Paths: "golf.hotel.india"},
+				{Name: "mike", OrgId: orgId2, Paths: "lima.mike"},
+			},
+		},
+
+		{
+			testName:   "Reparent only reports the org's folders.",
+			folderName: "golf",
+			strategy:   folder.DeleteReparent,
+			expect: folder.DeleteResult{
+				Removed: []folder.Folder{{Name: "golf", OrgId: orgId1, Paths: "golf"}},
+				Rewritten: []folder.PathChange{
+					{Folder: folder.Folder{Name: "juliet", OrgId: orgId1, Paths: "hotel.juliet"}, OldPaths: "golf.hotel.juliet"},
+					{Folder: folder.Folder{Name: "kilo", OrgId: orgId1, Paths: "kilo"}, OldPaths: "golf.kilo"},
+				},
+			},
+			expectOrg2: []folder.Folder{
+				{Name: "hotel", OrgId: orgId2, Paths: "hotel"},
+				{Name: "india", OrgId: orgId2, Paths: "hotel.india"},
+				{Name: "mike", OrgId: orgId2, Paths: "lima.mike"},
+			},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Refuse strategy with the org's folders below another org.",
+			folderName:  "golf",
+			strategy:    folder.DeleteRefuseIfNonEmpty,
+			expectError: folder.ErrFolderNotEmpty,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			result, err := f.DeleteFolder(orgId1, tc.folderName, tc.strategy)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+				return
+			}
+
+			assert.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expect, result, "unexpected result")
+
+			after, err := f.GetFoldersByOrgID(orgId2)
+			assert.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectOrg2, after, "other org changed")
+		})
+	}
+}
@@ -7,6 +7,9 @@ var ErrUnexpectedError = errors.New("unexpected error")
 // get_folder errors
 var ErrFolderDoesNotExist = errors.New("folder doesn't exist")
 var ErrFolderDoesNotExistInOrg = errors.New("folder doesn't exist in the specified organization")
+var ErrInvalidDepth = errors.New("depth must be at least 1")
+var ErrFolderHasNoParent = errors.New("folder has no parent in the specified organization")
+var ErrNoCommonAncestor = errors.New("folders have no common ancestor in the specified organization")
 
 // move_folder errors
 var ErrInvalidArguments = errors.New("empty folder name in source or destination")
@@ -16,3 +19,54 @@ var ErrMoveToDifferentOrg = errors.New("cannot move a folder to a different orga
 
 var ErrSourceDoesNotExist = errors.New("source folder doesn't exist")
 var ErrDestDoesNotExist = errors.New("destination folder doesn't exist")
+var ErrAlreadyRoot = errors.New("folder is already a root")
+
+// create_folder errors
+var ErrInvalidFolderName = errors.New("folder name must be a non-empty ltree label (letters, digits, '_' or '-')")
+var ErrFolderAlreadyExists = errors.New("folder already exists")
+
+// delete_folder errors
+var ErrFolderNotEmpty = errors.New("folder has children")
+var ErrUnknownDeleteStrategy = errors.New("unknown delete strategy")
+
+// copy_folder errors
+var ErrCopyToDifferentOrg = errors.New("cannot copy a folder to a different organization")
+var ErrNameGenerationFailed = errors.New("could not generate a unique folder name")
+
+// tree errors
+var ErrDuplicateFolderPath = errors.New("more than one folder has the same path in an organization")
+var ErrDuplicateFolderID = errors.New("more than one folder has the same ID")
+var ErrAmbiguousFolderName = errors.New("folder name matches more than one folder")
+
+// validate errors
+var ErrInvalidDataset = errors.New("folders failed validation")
+
+// lquery errors
+var ErrInvalidPattern = errors.New("invalid lquery pattern")
+
+// ltxtquery errors
+var ErrInvalidTextQuery = errors.New("invalid ltxtquery")
+
+// search errors
+var ErrEmptySearchQuery = errors.New("search query is empty")
+var ErrUnknownSearchMode = errors.New("unknown search mode")
+
+// paginate errors
+var ErrInvalidPageSize = errors.New("page size must be at least 1")
+var ErrUnknownSortOrder = errors.New("unknown sort order")
+var ErrInvalidCursor = errors.New("invalid page cursor")
+
+// sibling_order errors
+var ErrInvalidPosition = errors.New("position is out of range")
+var ErrInvalidChildOrder = errors.New("names must list each child of the folder exactly once")
+
+// apply_moves errors
+var ErrBatchMoveFailed = errors.New("batch of moves failed, nothing was moved")
+
+// transaction errors
+var ErrTxClosed = errors.New("transaction has already been committed or rolled back")
+var ErrTxConflict = errors.New("driver changed since the transaction began")
+
+// preview_move errors
+var ErrMaxDepthExceeded = errors.New("move would nest folders deeper than MaxDepth")
+var ErrMaxChildExceeded = errors.New("move would give the destination more than MaxChild children")
@@ -1,44 +1,186 @@
 package folder
 
-import "github.com/gofrs/uuid"
+import (
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/gofrs/uuid"
+)
 
 type IDriver interface {
 	// GetFoldersByOrgID returns all folders that belong to a specific orgID.
 	GetFoldersByOrgID(orgID uuid.UUID) ([]Folder, error)
+	// ListOrgs returns a summary of every organization in the driver.
+	ListOrgs() []OrgSummary
 	// component 1
 	// Implement the following methods:
 	// GetAllChildFolders returns all child folders of a specific folder.
 	GetAllChildFolders(orgID uuid.UUID, name string) ([]Folder, error)
+	// GetAllChildFoldersByID is GetAllChildFolders for the folder with the given ID.
+	GetAllChildFoldersByID(orgID uuid.UUID, id uuid.UUID) ([]Folder, error)
+	// GetFoldersByOrgIDPage is GetFoldersByOrgID one sorted page at a time.
+	GetFoldersByOrgIDPage(orgID uuid.UUID, opts PageOptions) (Page, error)
+	// GetAllChildFoldersPage is GetAllChildFolders one sorted page at a time.
+	GetAllChildFoldersPage(orgID uuid.UUID, name string, opts PageOptions) (Page, error)
+	// GetChildren returns the folders directly below a folder.
+	GetChildren(orgID uuid.UUID, name string) ([]Folder, error)
+	// GetDescendants returns the folders at most maxDepth levels below a folder.
+	GetDescendants(orgID uuid.UUID, name string, maxDepth int) ([]Folder, error)
+	// GetParent returns the folder directly above a folder.
+	GetParent(orgID uuid.UUID, name string) (Folder, error)
+	// GetAncestors returns every folder above a folder, root first.
+	GetAncestors(orgID uuid.UUID, name string) ([]Folder, error)
+	// GetSiblings returns the other folders sharing a folder's parent.
+	GetSiblings(orgID uuid.UUID, name string) ([]Folder, error)
+	// IterPreOrder yields the descendants of a folder, each one before its own descendants.
+	IterPreOrder(orgID uuid.UUID, name string) (iter.Seq[Folder], error)
+	// IterPostOrder yields the descendants of a folder, each one after its own descendants.
+	IterPostOrder(orgID uuid.UUID, name string) (iter.Seq[Folder], error)
+	// IterBreadthFirst yields the descendants of a folder, level by level.
+	IterBreadthFirst(orgID uuid.UUID, name string) (iter.Seq[Folder], error)
+	// IterAncestors yields the folders above a folder, starting from its parent.
+	IterAncestors(orgID uuid.UUID, name string) (iter.Seq[Folder], error)
+	// LowestCommonAncestor returns the deepest folder that is, or is above, both a and b.
+	LowestCommonAncestor(orgID uuid.UUID, a string, b string) (Folder, error)
+	// PathBetween returns the folders on the way from a up to their common ancestor and down to b.
+	PathBetween(orgID uuid.UUID, a string, b string) ([]Folder, error)
+	// Stats returns size and shape figures for the subtree rooted at name.
+	Stats(orgID uuid.UUID, name string) (SubtreeStats, error)
+	// Match returns the folders of an org whose path matches an ltree lquery pattern.
+	Match(orgID uuid.UUID, pattern string) ([]Folder, error)
+	// MatchText returns the folders of an org whose path labels satisfy an ltree ltxtquery.
+	MatchText(orgID uuid.UUID, query string) ([]Folder, error)
+	// SearchByName returns the folders of an org whose name matches query, best matches first.
+	SearchByName(orgID uuid.UUID, query string, mode SearchMode) ([]Folder, error)
 
 	// component 2
 	// Implement the following methods:
 	// MoveFolder moves a folder to a new destination.
 	MoveFolder(name string, dst string) ([]Folder, error)
+	// MoveFolderByID is MoveFolder for folders identified by ID.
+	MoveFolderByID(id uuid.UUID, dstID uuid.UUID) ([]Folder, error)
+	// MoveFolderToRoot detaches a folder from its parent, making it a top-level folder.
+	MoveFolderToRoot(name string) ([]Folder, error)
+	// MoveFolderInOrg moves a folder within orgID, returning only that org's folders.
+	MoveFolderInOrg(orgID uuid.UUID, name string, dst string) ([]Folder, error)
+	// ApplyMoves runs a batch of moves in order, applying all of them or none.
+	ApplyMoves(moves []Move) ([]Folder, []MoveOutcome, error)
+	// PreviewMove reports what a move would change and which policies it would break, without moving anything.
+	PreviewMove(move Move) (MovePreview, error)
+	// MoveFolderToIndex moves a folder within orgID to be the child at index of dst.
+	MoveFolderToIndex(orgID uuid.UUID, name string, dst string, index int) ([]Folder, error)
+	// MoveFolderBefore moves a folder within orgID to sit just before sibling.
+	MoveFolderBefore(orgID uuid.UUID, name string, sibling string) ([]Folder, error)
+	// MoveFolderAfter moves a folder within orgID to sit just after sibling.
+	MoveFolderAfter(orgID uuid.UUID, name string, sibling string) ([]Folder, error)
+	// ReorderChildren puts the children of a folder in the order given by names.
+	ReorderChildren(orgID uuid.UUID, parentName string, names []string) ([]Folder, error)
+
+	// CreateFolder adds a new folder under an existing parent folder of the same org.
+	CreateFolder(orgID uuid.UUID, parentName string, name string) (Folder, error)
+	// CreateRootFolder adds a new top-level folder to an org.
+	CreateRootFolder(orgID uuid.UUID, name string) (Folder, error)
+
+	// DeleteFolder removes a folder, handling its children according to strategy.
+	DeleteFolder(orgID uuid.UUID, name string, strategy DeleteStrategy) (DeleteResult, error)
+
+	// RenameFolder renames a folder and rewrites the paths of it and its descendants.
+	RenameFolder(orgID uuid.UUID, oldName string, newName string) ([]PathChange, error)
+
+	// CopyFolder duplicates a folder and its descendants under dst, naming the copies with namer.
+	CopyFolder(name string, dst string, namer Namer) ([]FolderCopy, error)
+
+	// Begin starts a transaction over a copy of the driver's folders.
+	Begin() Tx
 }
 
 type FolderNode struct {
 	Folder   *Folder
+	Parent   *FolderNode
 	Children []*FolderNode
 }
 
+// PathChange records a folder whose Paths was rewritten by an operation.
+type PathChange struct {
+	// the folder after the change
+	Folder   Folder
+	OldPaths string
+}
+
 type driver struct {
-	folderNames []string
-	nameToNode  map[string]*FolderNode
+	// every folder in insertion order
+	nodes []*FolderNode
+	// names aren't unique, so a name can map to several folders
+	nameToNodes map[string][]*FolderNode
+	// folders without an ID aren't in here
+	idToNode map[uuid.UUID]*FolderNode
+	// names by org for SearchByName, maintained alongside nameToNodes
+	searchIndex nameIndex
+	// bumped on every change to the tree, so a Tx can tell if it is stale
+	version int
+
+	// when set, moves are applied to the driver's own tree instead of
+	// returning a moved copy of the folders.
+	stateful bool
+	// when set, NewDriver refuses folders that fail Validate.
+	strict bool
 }
 
-func NewDriver(folders []Folder) (IDriver, error) {
-	var folderNames []string
-	for _, folder := range folders {
-		folderNames = append(folderNames, folder.Name)
+// DriverOption configures optional behaviour of a driver created by NewDriver.
+type DriverOption func(*driver)
+
+// WithStatefulMoves makes MoveFolder apply each move to the driver's tree,
+// so later calls on the same driver see the moved folders.
+func WithStatefulMoves() DriverOption {
+	return func(d *driver) {
+		d.stateful = true
 	}
+}
+
+// WithValidation makes NewDriver fail with ErrInvalidDataset if Validate finds
+// any issue, rather than building a tree that quietly leaves folders out.
+func WithValidation() DriverOption {
+	return func(d *driver) {
+		d.strict = true
+	}
+}
 
-	nameToNode, err := BuildFolderTree(folders, folderNames)
+// returns a driver with no folders.
+func newDriver() *driver {
+	return &driver{
+		nameToNodes: map[string][]*FolderNode{},
+		idToNode:    map[uuid.UUID]*FolderNode{},
+		searchIndex: nameIndex{},
+	}
+}
+
+func NewDriver(folders []Folder, opts ...DriverOption) (IDriver, error) {
+	d := newDriver()
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	if d.strict {
+		if report := Validate(folders); !report.OK() {
+			return nil, fmt.Errorf("%w: %d issues, first: %s", ErrInvalidDataset, len(report.Issues), report.Issues[0])
+		}
+	}
+
+	// the tree points into this slice, so keep our own copy to avoid
+	// mutating the caller's folders.
+	folders = slices.Clone(folders)
+
+	nodes, err := BuildFolderTree(folders)
 	if err != nil {
 		return nil, err
 	}
 
-	return &driver{
-		folderNames: folderNames,
-		nameToNode:  nameToNode,
-	}, nil
+	d.nodes = nodes
+	for _, node := range nodes {
+		d.indexNode(node)
+	}
+
+	return d, nil
 }
@@ -1,6 +1,8 @@
 package folder
 
 import (
+	"slices"
+
 	"github.com/gofrs/uuid"
 )
 
@@ -10,12 +12,7 @@ func GetAllFolders() []Folder {
 
 func (d *driver) GetFoldersByOrgID(orgID uuid.UUID) ([]Folder, error) {
 	res := []Folder{}
-	for _, name := range d.folderNames {
-		node, ok := d.nameToNode[name]
-		if !ok {
-			return nil, ErrUnexpectedError
-		}
-
+	for _, node := range d.nodes {
 		folder := node.Folder
 
 		if folder.OrgId == orgID {
@@ -26,32 +23,138 @@ func (d *driver) GetFoldersByOrgID(orgID uuid.UUID) ([]Folder, error) {
 	return res, nil
 }
 
-func (d *driver) GetAllChildFolders(orgID uuid.UUID, name string) ([]Folder, error) {
-	node, folderExists := d.nameToNode[name]
+// returns a copy of every folder in the driver, in insertion order.
+func (d *driver) allFolders() []Folder {
+	res := []Folder{}
+	for _, node := range d.nodes {
+		res = append(res, *node.Folder)
+	}
+
+	return res
+}
 
-	if !folderExists {
-		return nil, ErrFolderDoesNotExist
+func (d *driver) GetAllChildFolders(orgID uuid.UUID, name string) ([]Folder, error) {
+	node, err := d.lookupInOrg(orgID, name)
+	if err != nil {
+		return nil, err
 	}
 
-	folder := node.Folder
+	res := []Folder{}
+	getDescendants(&res, node, orgID, unlimitedDepth)
+
+	return res, nil
+}
+
+func (d *driver) GetAllChildFoldersByID(orgID uuid.UUID, id uuid.UUID) ([]Folder, error) {
+	node, err := d.lookupID(id)
+	if err != nil {
+		return nil, err
+	}
 
-	if folder.OrgId != orgID {
+	if node.Folder.OrgId != orgID {
 		return nil, ErrFolderDoesNotExistInOrg
 	}
 
 	res := []Folder{}
-	getDescendants(&res, node, orgID)
+	getDescendants(&res, node, orgID, unlimitedDepth)
 
 	return res, nil
 }
 
-// adds all descendants of node to res
-func getDescendants(res *[]Folder, node *FolderNode, orgID uuid.UUID) {
+func (d *driver) GetChildren(orgID uuid.UUID, name string) ([]Folder, error) {
+	return d.GetDescendants(orgID, name, 1)
+}
+
+func (d *driver) GetDescendants(orgID uuid.UUID, name string, maxDepth int) ([]Folder, error) {
+	if maxDepth < 1 {
+		return nil, ErrInvalidDepth
+	}
+
+	node, err := d.lookupInOrg(orgID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []Folder{}
+	getDescendants(&res, node, orgID, maxDepth)
+
+	return res, nil
+}
+
+// passed to getDescendants to walk the whole subtree
+const unlimitedDepth = -1
+
+// adds all descendants of node to res, stopping maxDepth levels below node
+func getDescendants(res *[]Folder, node *FolderNode, orgID uuid.UUID, maxDepth int) {
+	if maxDepth == 0 {
+		return
+	}
+
 	for _, child := range node.Children {
 		if child.Folder.OrgId == orgID {
 			*res = append(*res, *child.Folder)
 		}
 
-		getDescendants(res, child, orgID)
+		getDescendants(res, child, orgID, maxDepth-1)
 	}
 }
+
+func (d *driver) GetParent(orgID uuid.UUID, name string) (Folder, error) {
+	node, err := d.lookupInOrg(orgID, name)
+	if err != nil {
+		return Folder{}, err
+	}
+
+	parent := node.Parent
+	if parent == nil || parent.Folder.OrgId != orgID {
+		return Folder{}, ErrFolderHasNoParent
+	}
+
+	return *parent.Folder, nil
+}
+
+func (d *driver) GetAncestors(orgID uuid.UUID, name string) ([]Folder, error) {
+	node, err := d.lookupInOrg(orgID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []Folder{}
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		if parent.Folder.OrgId == orgID {
+			res = append(res, *parent.Folder)
+		}
+	}
+
+	slices.Reverse(res)
+
+	return res, nil
+}
+
+func (d *driver) GetSiblings(orgID uuid.UUID, name string) ([]Folder, error) {
+	node, err := d.lookupInOrg(orgID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// roots, and orphans under the same missing parent, have no parent node to ask.
+	siblings := d.nodes
+	if node.Parent != nil {
+		siblings = node.Parent.Children
+	}
+
+	res := []Folder{}
+	for _, sibling := range siblings {
+		if sibling == node || sibling.Parent != node.Parent || sibling.Folder.OrgId != orgID {
+			continue
+		}
+
+		if node.Parent == nil && parentPath(sibling.Folder.Paths) != parentPath(node.Folder.Paths) {
+			continue
+		}
+
+		res = append(res, *sibling.Folder)
+	}
+
+	return res, nil
+}
@@ -362,3 +362,449 @@ func Test_folder_GetAllChildFolders(t *testing.T) {
 	}
 
 }
+
+func Test_folder_GetAllChildFolders_DuplicateNames(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "reports", OrgId: orgId1, Paths: "alpha.reports"},
+		{Name: "q1", OrgId: orgId1, Paths: "alpha.reports.q1"},
+		{Name: "bravo", OrgId: orgId1, Paths: "bravo"},
+		{Name: "reports", OrgId: orgId1, Paths: "bravo.reports"},
+		{Name: "q2", OrgId: orgId1, Paths: "bravo.reports.q2"},
+		{Name: "bravo", OrgId: orgId2, Paths: "bravo"},
+		{Name: "q3", OrgId: orgId2, Paths: "bravo.q3"},
+	}
+
+	f, err := folder.NewDriver(folders)
+	assert.NoError(t, err, "unexpected error")
+
+	// the org picks between folders with the same name.
+	result, err := f.GetAllChildFolders(orgId1, "bravo")
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, []folder.Folder{
+		{Name: "reports", OrgId: orgId1, Paths: "bravo.reports"},
+		{Name: "q2", OrgId: orgId1, Paths: "bravo.reports.q2"},
+	}, result, "unexpected result")
+
+	result, err = f.GetAllChildFolders(orgId2, "bravo")
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, []folder.Folder{
+		{Name: "q3", OrgId: orgId2, Paths: "bravo.q3"},
+	}, result, "unexpected result")
+
+	// children are linked by full path, not just their parent's name.
+	result, err = f.GetAllChildFolders(orgId1, "alpha")
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, []folder.Folder{
+		{Name: "reports", OrgId: orgId1, Paths: "alpha.reports"},
+		{Name: "q1", OrgId: orgId1, Paths: "alpha.reports.q1"},
+	}, result, "unexpected result")
+
+	_, err = f.GetAllChildFolders(orgId1, "reports")
+	assert.ErrorIs(t, err, folder.ErrAmbiguousFolderName)
+
+	_, err = f.GetAllChildFolders(orgId2, "reports")
+	assert.ErrorIs(t, err, folder.ErrFolderDoesNotExistInOrg)
+}
+
+func Test_folder_NewDriver_DuplicatePaths(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	_, err := folder.NewDriver([]folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+	})
+	assert.ErrorIs(t, err, folder.ErrDuplicateFolderPath)
+}
+
+func Test_folder_GetAllChildFoldersByID(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	alphaID := uuid.Must(uuid.NewV4())
+	bravoID := uuid.Must(uuid.NewV4())
+	reportsID := uuid.Must(uuid.NewV4())
+	foxtrotID := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{ID: alphaID, Name: "reports", OrgId: orgId1, Paths: "reports"},
+		{ID: bravoID, Name: "bravo", OrgId: orgId1, Paths: "reports.bravo"},
+		{ID: reportsID, Name: "reports", OrgId: orgId1, Paths: "reports.bravo.reports"},
+		{Name: "charlie", OrgId: orgId1, Paths: "reports.bravo.reports.charlie"},
+		{ID: foxtrotID, Name: "foxtrot", OrgId: orgId2, Paths: "foxtrot"},
+	}
+
+	tests := [...]struct {
+		testName    string
+		orgID       uuid.UUID
+		id          uuid.UUID
+		expect      []folder.Folder
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName: "IDs tell apart folders with the same name.",
+			orgID:    orgId1,
+			id:       reportsID,
+			expect: []folder.Folder{
+				{Name: "charlie", OrgId: orgId1, Paths: "reports.bravo.reports.charlie"},
+			},
+		},
+
+		{
+			testName: "Fetches all descendants.",
+			orgID:    orgId1,
+			id:       alphaID,
+			expect: []folder.Folder{
+				{ID: bravoID, Name: "bravo", OrgId: orgId1, Paths: "reports.bravo"},
+				{ID: reportsID, Name: "reports", OrgId: orgId1, Paths: "reports.bravo.reports"},
+				{Name: "charlie", OrgId: orgId1, Paths: "reports.bravo.reports.charlie"},
+			},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Unknown ID.",
+			orgID:       orgId1,
+			id:          uuid.Must(uuid.NewV4()),
+			expectError: folder.ErrFolderDoesNotExist,
+		},
+
+		{
+			testName:    "Folders without an ID cant be found by the nil ID.",
+			orgID:       orgId1,
+			id:          uuid.Nil,
+			expectError: folder.ErrFolderDoesNotExist,
+		},
+
+		{
+			testName:    "Folder in another org.",
+			orgID:       orgId1,
+			id:          foxtrotID,
+			expectError: folder.ErrFolderDoesNotExistInOrg,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			result, err := f.GetAllChildFoldersByID(tc.orgID, tc.id)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+				return
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+
+			assert.Equal(t, tc.expect, result, "unexpected result")
+		})
+	}
+}
+
+func Test_folder_NewDriver_DuplicateIDs(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	id := uuid.Must(uuid.NewV4())
+
+	_, err := folder.NewDriver([]folder.Folder{
+		{ID: id, Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{ID: id, Name: "bravo", OrgId: orgId1, Paths: "bravo"},
+	})
+	assert.ErrorIs(t, err, folder.ErrDuplicateFolderID)
+}
+
+func Test_folder_FolderIDs(t *testing.T) {
+	t.Parallel()
+
+	for name, folders := range map[string][]folder.Folder{
+		"sample data":    folder.GetSampleData(),
+		"generated data": folder.GenerateData(),
+	} {
+		seen := map[uuid.UUID]bool{}
+		for _, f := range folders {
+			assert.NotEqual(t, uuid.Nil, f.ID, "%s: folder %s has no ID", name, f.Name)
+			assert.False(t, seen[f.ID], "%s: duplicate ID %s", name, f.ID)
+			seen[f.ID] = true
+		}
+
+		_, err := folder.NewDriver(folders)
+		assert.NoError(t, err, "unexpected error")
+	}
+}
+
+func Test_folder_GetRelatives(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: orgId1, Paths: "alpha.bravo.delta"},
+		{Name: "echo", OrgId: orgId2, Paths: "alpha.bravo.echo"},
+		{Name: "foxtrot", OrgId: orgId1, Paths: "alpha.foxtrot"},
+		{Name: "golf", OrgId: orgId1, Paths: "golf"},
+		{Name: "hotel", OrgId: orgId2, Paths: "hotel"},
+	}
+
+	tests := [...]struct {
+		testName        string
+		orgID           uuid.UUID
+		folderName      string
+		expectParent    folder.Folder
+		expectParentErr error
+		expectAncestors []folder.Folder
+		expectSiblings  []folder.Folder
+		expectError     error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName:     "Nested folder.",
+			orgID:        orgId1,
+			folderName:   "charlie",
+			expectParent: folder.Folder{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+			expectAncestors: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+			},
+			expectSiblings: []folder.Folder{
+				{Name: "delta", OrgId: orgId1, Paths: "alpha.bravo.delta"},
+			},
+		},
+
+		{
+			testName:     "Direct child of a root.",
+			orgID:        orgId1,
+			folderName:   "foxtrot",
+			expectParent: folder.Folder{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+			expectAncestors: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+			},
+			expectSiblings: []folder.Folder{
+				{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+			},
+		},
+
+		{
+			testName:        "Root folder siblings are the org's other roots.",
+			orgID:           orgId1,
+			folderName:      "alpha",
+			expectParentErr: folder.ErrFolderHasNoParent,
+			expectAncestors: []folder.Folder{},
+			expectSiblings: []folder.Folder{
+				{Name: "golf", OrgId: orgId1, Paths: "golf"},
+			},
+		},
+
+		{
+			testName:        "Parent in another org.",
+			orgID:           orgId2,
+			folderName:      "echo",
+			expectParentErr: folder.ErrFolderHasNoParent,
+			expectAncestors: []folder.Folder{},
+			expectSiblings:  []folder.Folder{},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Folder doesnt exist.",
+			orgID:       orgId1,
+			folderName:  "x",
+			expectError: folder.ErrFolderDoesNotExist,
+		},
+
+		{
+			testName:    "Folder in another org.",
+			orgID:       orgId1,
+			folderName:  "hotel",
+			expectError: folder.ErrFolderDoesNotExistInOrg,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			parent, parentErr := f.GetParent(tc.orgID, tc.folderName)
+			ancestors, ancestorsErr := f.GetAncestors(tc.orgID, tc.folderName)
+			siblings, siblingsErr := f.GetSiblings(tc.orgID, tc.folderName)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, parentErr, tc.expectError, "expected error")
+				assert.ErrorIs(t, ancestorsErr, tc.expectError, "expected error")
+				assert.ErrorIs(t, siblingsErr, tc.expectError, "expected error")
+				return
+			}
+
+			if tc.expectParentErr != nil {
+				assert.ErrorIs(t, parentErr, tc.expectParentErr, "expected error")
+			} else {
+				assert.NoError(t, parentErr, "unexpected error")
+				assert.Equal(t, tc.expectParent, parent, "unexpected parent")
+			}
+
+			assert.NoError(t, ancestorsErr, "unexpected error")
+			assert.Equal(t, tc.expectAncestors, ancestors, "unexpected ancestors")
+
+			assert.NoError(t, siblingsErr, "unexpected error")
+			assert.Equal(t, tc.expectSiblings, siblings, "unexpected siblings")
+		})
+	}
+}
+
+func Test_folder_GetDescendants(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: orgId1, Paths: "alpha.bravo.charlie.delta"},
+		{Name: "echo", OrgId: orgId2, Paths: "alpha.echo"},
+		{Name: "foxtrot", OrgId: orgId1, Paths: "alpha.echo.foxtrot"},
+		{Name: "golf", OrgId: orgId1, Paths: "alpha.golf"},
+		{Name: "hotel", OrgId: orgId2, Paths: "hotel"},
+	}
+
+	tests := [...]struct {
+		testName    string
+		orgID       uuid.UUID
+		folderName  string
+		maxDepth    int
+		expect      []folder.Folder
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName:   "Depth 1 is the direct children.",
+			orgID:      orgId1,
+			folderName: "alpha",
+			maxDepth:   1,
+			expect: []folder.Folder{
+				{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+				{Name: "golf", OrgId: orgId1, Paths: "alpha.golf"},
+			},
+		},
+
+		{
+			testName:   "Depth 2.",
+			orgID:      orgId1,
+			folderName: "alpha",
+			maxDepth:   2,
+			expect: []folder.Folder{
+				{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+				{Name: "foxtrot", OrgId: orgId1, Paths: "alpha.echo.foxtrot"},
+				{Name: "golf", OrgId: orgId1, Paths: "alpha.golf"},
+			},
+		},
+
+		{
+			testName:   "Depth past the bottom of the tree.",
+			orgID:      orgId1,
+			folderName: "bravo",
+			maxDepth:   10,
+			expect: []folder.Folder{
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+				{Name: "delta", OrgId: orgId1, Paths: "alpha.bravo.charlie.delta"},
+			},
+		},
+
+		{
+			testName:   "Leaf folder.",
+			orgID:      orgId1,
+			folderName: "delta",
+			maxDepth:   1,
+			expect:     []folder.Folder{},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Depth below 1.",
+			orgID:       orgId1,
+			folderName:  "alpha",
+			maxDepth:    0,
+			expectError: folder.ErrInvalidDepth,
+		},
+
+		{
+			testName:    "Folder doesnt exist.",
+			orgID:       orgId1,
+			folderName:  "x",
+			maxDepth:    1,
+			expectError: folder.ErrFolderDoesNotExist,
+		},
+
+		{
+			testName:    "Folder in another org.",
+			orgID:       orgId1,
+			folderName:  "hotel",
+			maxDepth:    1,
+			expectError: folder.ErrFolderDoesNotExistInOrg,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			result, err := f.GetDescendants(tc.orgID, tc.folderName, tc.maxDepth)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+				return
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+
+			assert.Equal(t, tc.expect, result, "unexpected result")
+
+			if tc.maxDepth == 1 {
+				children, err := f.GetChildren(tc.orgID, tc.folderName)
+				assert.NoError(t, err, "unexpected error")
+				assert.Equal(t, tc.expect, children, "GetChildren should match depth 1")
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package folder
+
+import (
+	"iter"
+
+	"github.com/gofrs/uuid"
+)
+
+// the Iter methods look the folder up straight away, then walk the tree
+// lazily as the sequence is ranged over. changing the driver while ranging
+// over one of these sequences gives undefined results.
+
+func (d *driver) IterPreOrder(orgID uuid.UUID, name string) (iter.Seq[Folder], error) {
+	node, err := d.lookupInOrg(orgID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(Folder) bool) {
+		for _, child := range node.Children {
+			if !preOrder(child, orgID, yield) {
+				return
+			}
+		}
+	}, nil
+}
+
+func (d *driver) IterPostOrder(orgID uuid.UUID, name string) (iter.Seq[Folder], error) {
+	node, err := d.lookupInOrg(orgID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(Folder) bool) {
+		for _, child := range node.Children {
+			if !postOrder(child, orgID, yield) {
+				return
+			}
+		}
+	}, nil
+}
+
+func (d *driver) IterBreadthFirst(orgID uuid.UUID, name string) (iter.Seq[Folder], error) {
+	node, err := d.lookupInOrg(orgID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(Folder) bool) {
+		queue := node.Children
+		for len(queue) > 0 {
+			next := queue[0]
+			queue = queue[1:]
+
+			if next.Folder.OrgId == orgID && !yield(*next.Folder) {
+				return
+			}
+
+			// queue may share its array with a node's Children, so never
+			// append to it in place.
+			queue = append(queue[:len(queue):len(queue)], next.Children...)
+		}
+	}, nil
+}
+
+func (d *driver) IterAncestors(orgID uuid.UUID, name string) (iter.Seq[Folder], error) {
+	node, err := d.lookupInOrg(orgID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(Folder) bool) {
+		for parent := node.Parent; parent != nil; parent = parent.Parent {
+			if parent.Folder.OrgId == orgID && !yield(*parent.Folder) {
+				return
+			}
+		}
+	}, nil
+}
+
+// yields node and then its descendants, returning false once yield has.
+func preOrder(node *FolderNode, orgID uuid.UUID, yield func(Folder) bool) bool {
+	if node.Folder.OrgId == orgID && !yield(*node.Folder) {
+		return false
+	}
+
+	for _, child := range node.Children {
+		if !preOrder(child, orgID, yield) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// yields the descendants of node and then node, returning false once yield has.
+func postOrder(node *FolderNode, orgID uuid.UUID, yield func(Folder) bool) bool {
+	for _, child := range node.Children {
+		if !postOrder(child, orgID, yield) {
+			return false
+		}
+	}
+
+	return node.Folder.OrgId != orgID || yield(*node.Folder)
+}
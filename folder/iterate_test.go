@@ -0,0 +1,172 @@
+package folder_test
+
+import (
+	"iter"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_folder_Iter(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: orgId1, Paths: "alpha.bravo.delta"},
+		{Name: "xray", OrgId: orgId2, Paths: "alpha.bravo.xray"},
+		{Name: "yankee", OrgId: orgId1, Paths: "alpha.bravo.xray.yankee"},
+		{Name: "echo", OrgId: orgId1, Paths: "alpha.echo"},
+		{Name: "foxtrot", OrgId: orgId1, Paths: "alpha.echo.foxtrot"},
+	}
+
+	preOrder := func(f folder.IDriver, name string) (iter.Seq[folder.Folder], error) {
+		return f.IterPreOrder(orgId1, name)
+	}
+	postOrder := func(f folder.IDriver, name string) (iter.Seq[folder.Folder], error) {
+		return f.IterPostOrder(orgId1, name)
+	}
+	breadthFirst := func(f folder.IDriver, name string) (iter.Seq[folder.Folder], error) {
+		return f.IterBreadthFirst(orgId1, name)
+	}
+	ancestors := func(f folder.IDriver, name string) (iter.Seq[folder.Folder], error) {
+		return f.IterAncestors(orgId1, name)
+	}
+
+	tests := [...]struct {
+		testName string
+		iterate  func(folder.IDriver, string) (iter.Seq[folder.Folder], error)
+		name     string
+		// stop after this many folders, 0 ranges over everything
+		limit       int
+		expect      []string
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName: "Pre-order.",
+			iterate:  preOrder,
+			name:     "alpha",
+			expect:   []string{"bravo", "charlie", "delta", "yankee", "echo", "foxtrot"},
+		},
+
+		{
+			testName: "Post-order.",
+			iterate:  postOrder,
+			name:     "alpha",
+			expect:   []string{"charlie", "delta", "yankee", "bravo", "foxtrot", "echo"},
+		},
+
+		{
+			testName: "Breadth-first.",
+			iterate:  breadthFirst,
+			name:     "alpha",
+			expect:   []string{"bravo", "echo", "charlie", "delta", "foxtrot", "yankee"},
+		},
+
+		{
+			testName: "Ancestors.",
+			iterate:  ancestors,
+			name:     "yankee",
+			expect:   []string{"bravo", "alpha"},
+		},
+
+		{
+			testName: "Pre-order stops early.",
+			iterate:  preOrder,
+			name:     "alpha",
+			limit:    3,
+			expect:   []string{"bravo", "charlie", "delta"},
+		},
+
+		{
+			testName: "Post-order stops early.",
+			iterate:  postOrder,
+			name:     "alpha",
+			limit:    4,
+			expect:   []string{"charlie", "delta", "yankee", "bravo"},
+		},
+
+		{
+			testName: "Breadth-first stops early.",
+			iterate:  breadthFirst,
+			name:     "alpha",
+			limit:    2,
+			expect:   []string{"bravo", "echo"},
+		},
+
+		{
+			testName: "Ancestors stop early.",
+			iterate:  ancestors,
+			name:     "foxtrot",
+			limit:    1,
+			expect:   []string{"echo"},
+		},
+
+		{
+			testName: "Leaf has no descendants.",
+			iterate:  breadthFirst,
+			name:     "charlie",
+			expect:   []string{},
+		},
+
+		{
+			testName: "Root has no ancestors.",
+			iterate:  ancestors,
+			name:     "alpha",
+			expect:   []string{},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Folder doesnt exist.",
+			iterate:     preOrder,
+			name:        "x",
+			expectError: folder.ErrFolderDoesNotExist,
+		},
+
+		{
+			testName:    "Folder in another org.",
+			iterate:     ancestors,
+			name:        "xray",
+			expectError: folder.ErrFolderDoesNotExistInOrg,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			seq, err := tc.iterate(f, tc.name)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+				return
+			}
+
+			assert.NoError(t, err, "unexpected error")
+			names := []string{}
+			for f := range seq {
+				names = append(names, f.Name)
+				if len(names) == tc.limit {
+					break
+				}
+			}
+			assert.Equal(t, tc.expect, names, "unexpected traversal")
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package folder
+
+import (
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// OrgSummary describes one organization found in the driver.
+type OrgSummary struct {
+	OrgID       uuid.UUID
+	FolderCount int
+	// folders without a parent in the same org, in insertion order
+	Roots []Folder
+	// number of path segments in the org's deepest folder, roots are at depth 1
+	MaxDepth int
+}
+
+// ListOrgs returns a summary for every organization, in order of first appearance.
+func (d *driver) ListOrgs() []OrgSummary {
+	res := []OrgSummary{}
+	orgIndex := map[uuid.UUID]int{}
+
+	for _, node := range d.nodes {
+		folder := node.Folder
+
+		i, ok := orgIndex[folder.OrgId]
+		if !ok {
+			i = len(res)
+			orgIndex[folder.OrgId] = i
+			res = append(res, OrgSummary{OrgID: folder.OrgId, Roots: []Folder{}})
+		}
+
+		summary := &res[i]
+		summary.FolderCount++
+		summary.MaxDepth = max(summary.MaxDepth, strings.Count(folder.Paths, ".")+1)
+
+		if node.Parent == nil || node.Parent.Folder.OrgId != folder.OrgId {
+			summary.Roots = append(summary.Roots, *folder)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,92 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_folder_ListOrgs(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	tests := [...]struct {
+		testName string
+		folders  []folder.Folder
+		expect   []folder.OrgSummary
+	}{
+		{
+			testName: "No folders.",
+			folders:  []folder.Folder{},
+			expect:   []folder.OrgSummary{},
+		},
+
+		{
+			testName: "Orgs in order of first appearance.",
+			folders: []folder.Folder{
+				{Name: "alpha", OrgId: orgId2, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "bravo"},
+				{Name: "charlie", OrgId: orgId1, Paths: "bravo.charlie"},
+				{Name: "delta", OrgId: orgId1, Paths: "bravo.charlie.delta"},
+				{Name: "echo", OrgId: orgId2, Paths: "alpha.echo"},
+				{Name: "foxtrot", OrgId: orgId1, Paths: "foxtrot"},
+			},
+			expect: []folder.OrgSummary{
+				{
+					OrgID:       orgId2,
+					FolderCount: 2,
+					Roots:       []folder.Folder{{Name: "alpha", OrgId: orgId2, Paths: "alpha"}},
+					MaxDepth:    2,
+				},
+				{
+					OrgID:       orgId1,
+					FolderCount: 4,
+					Roots: []folder.Folder{
+						{Name: "bravo", OrgId: orgId1, Paths: "bravo"},
+						{Name: "foxtrot", OrgId: orgId1, Paths: "foxtrot"},
+					},
+					MaxDepth: 3,
+				},
+			},
+		},
+
+		{
+			testName: "Cross org child is a root of its own org.",
+			folders: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgId2, Paths: "alpha.bravo"},
+			},
+			expect: []folder.OrgSummary{
+				{
+					OrgID:       orgId1,
+					FolderCount: 1,
+					Roots:       []folder.Folder{{Name: "alpha", OrgId: orgId1, Paths: "alpha"}},
+					MaxDepth:    1,
+				},
+				{
+					OrgID:       orgId2,
+					FolderCount: 1,
+					Roots:       []folder.Folder{{Name: "bravo", OrgId: orgId2, Paths: "alpha.bravo"}},
+					MaxDepth:    2,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(tc.folders)
+			assert.NoError(t, err, "unexpected error")
+
+			assert.Equal(t, tc.expect, f.ListOrgs(), "unexpected summaries")
+		})
+	}
+}
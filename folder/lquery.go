@@ -0,0 +1,190 @@
+package folder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// a parsed ltree lquery, e.g. "top.*{1,2}.!archive|trash.report*"
+type lquery []lqueryLevel
+
+// one dot-separated part of an lquery.
+type lqueryLevel struct {
+	// star levels match between min and max labels; max < 0 means no upper bound.
+	// every other level matches exactly one label.
+	star bool
+	min  int
+	max  int
+
+	negate bool
+	alts   []lqueryLabel
+}
+
+type lqueryLabel struct {
+	text string
+	// matches any label starting with text
+	prefix bool
+}
+
+func (d *driver) Match(orgID uuid.UUID, pattern string) ([]Folder, error) {
+	query, err := parseLquery(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []Folder{}
+	for _, node := range d.nodes {
+		folder := node.Folder
+
+		if folder.OrgId == orgID && query.match(strings.Split(folder.Paths, ".")) {
+			res = append(res, *folder)
+		}
+	}
+
+	return res, nil
+}
+
+func parseLquery(pattern string) (lquery, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("%w: empty pattern", ErrInvalidPattern)
+	}
+
+	query := lquery{}
+	for _, part := range strings.Split(pattern, ".") {
+		level, err := parseLqueryLevel(part)
+		if err != nil {
+			return nil, err
+		}
+
+		query = append(query, level)
+	}
+
+	return query, nil
+}
+
+func parseLqueryLevel(part string) (lqueryLevel, error) {
+	if rest, ok := strings.CutPrefix(part, "*"); ok {
+		level := lqueryLevel{star: true, min: 0, max: -1}
+		if rest == "" {
+			return level, nil
+		}
+
+		min, max, err := parseQuantifier(rest)
+		if err != nil {
+			return lqueryLevel{}, err
+		}
+
+		level.min, level.max = min, max
+		return level, nil
+	}
+
+	level := lqueryLevel{min: 1, max: 1}
+	part, level.negate = strings.CutPrefix(part, "!")
+
+	for _, alt := range strings.Split(part, "|") {
+		label := lqueryLabel{}
+		label.text, label.prefix = strings.CutSuffix(alt, "*")
+
+		if validateFolderName(label.text) != nil {
+			return lqueryLevel{}, fmt.Errorf("%w: bad label %q", ErrInvalidPattern, alt)
+		}
+
+		level.alts = append(level.alts, label)
+	}
+
+	return level, nil
+}
+
+// parses "{n}", "{n,}", "{,m}" or "{n,m}".
+func parseQuantifier(s string) (int, int, error) {
+	inner, ok := strings.CutPrefix(s, "{")
+	if ok {
+		inner, ok = strings.CutSuffix(inner, "}")
+	}
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: bad quantifier %q", ErrInvalidPattern, s)
+	}
+
+	lo, hi, hasComma := strings.Cut(inner, ",")
+	if !hasComma {
+		hi = lo
+	}
+
+	min, max := 0, -1
+	var err error
+
+	if lo != "" {
+		if min, err = strconv.Atoi(lo); err != nil || min < 0 {
+			return 0, 0, fmt.Errorf("%w: bad quantifier %q", ErrInvalidPattern, s)
+		}
+	}
+
+	if hi != "" {
+		if max, err = strconv.Atoi(hi); err != nil || max < min {
+			return 0, 0, fmt.Errorf("%w: bad quantifier %q", ErrInvalidPattern, s)
+		}
+	}
+
+	if !hasComma && lo == "" {
+		return 0, 0, fmt.Errorf("%w: bad quantifier %q", ErrInvalidPattern, s)
+	}
+
+	return min, max, nil
+}
+
+func (q lquery) match(labels []string) bool {
+	// memo[i][j] caches whether q[i:] matches labels[j:]: 0 unknown, 1 yes, 2 no.
+	memo := make([][]byte, len(q)+1)
+	for i := range memo {
+		memo[i] = make([]byte, len(labels)+1)
+	}
+
+	var matchFrom func(i int, j int) bool
+	matchFrom = func(i int, j int) bool {
+		if i == len(q) {
+			return j == len(labels)
+		}
+
+		if memo[i][j] != 0 {
+			return memo[i][j] == 1
+		}
+
+		level := q[i]
+		res := false
+
+		if level.star {
+			for n := level.min; j+n <= len(labels) && (level.max < 0 || n <= level.max); n++ {
+				if matchFrom(i+1, j+n) {
+					res = true
+					break
+				}
+			}
+		} else {
+			res = j < len(labels) && level.matchLabel(labels[j]) && matchFrom(i+1, j+1)
+		}
+
+		memo[i][j] = 2
+		if res {
+			memo[i][j] = 1
+		}
+
+		return res
+	}
+
+	return matchFrom(0, 0)
+}
+
+func (l lqueryLevel) matchLabel(label string) bool {
+	matched := false
+	for _, alt := range l.alts {
+		if alt.text == label || (alt.prefix && strings.HasPrefix(label, alt.text)) {
+			matched = true
+			break
+		}
+	}
+
+	return matched != l.negate
+}
@@ -0,0 +1,216 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_folder_Match(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "top", OrgId: orgId1, Paths: "top"},
+		{Name: "europe", OrgId: orgId1, Paths: "top.europe"},
+		{Name: "sales", OrgId: orgId1, Paths: "top.europe.sales"},
+		{Name: "archive", OrgId: orgId1, Paths: "top.europe.archive"},
+		{Name: "asia", OrgId: orgId1, Paths: "top.asia"},
+		{Name: "sales_2024", OrgId: orgId1, Paths: "top.asia.sales_2024"},
+		{Name: "q1", OrgId: orgId1, Paths: "top.asia.sales_2024.q1"},
+		{Name: "other", OrgId: orgId2, Paths: "top"},
+		{Name: "sales", OrgId: orgId2, Paths: "top.sales"},
+	}
+
+	tests := [...]struct {
+		testName    string
+		orgID       uuid.UUID
+		pattern     string
+		expect      []string
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName: "Exact path.",
+			orgID:    orgId1,
+			pattern:  "top.europe",
+			expect:   []string{"top.europe"},
+		},
+
+		{
+			testName: "Star matches any number of labels.",
+			orgID:    orgId1,
+			pattern:  "*.sales",
+			expect:   []string{"top.europe.sales"},
+		},
+
+		{
+			testName: "Star matches zero labels too.",
+			orgID:    orgId1,
+			pattern:  "top.*",
+			expect: []string{
+				"top",
+				"top.europe",
+				"top.europe.sales",
+				"top.europe.archive",
+				"top.asia",
+				"top.asia.sales_2024",
+				"top.asia.sales_2024.q1",
+			},
+		},
+
+		{
+			testName: "Bounded star.",
+			orgID:    orgId1,
+			pattern:  "top.*{1}",
+			expect:   []string{"top.europe", "top.asia"},
+		},
+
+		{
+			testName: "Star range.",
+			orgID:    orgId1,
+			pattern:  "top.*{2,3}",
+			expect: []string{
+				"top.europe.sales",
+				"top.europe.archive",
+				"top.asia.sales_2024",
+				"top.asia.sales_2024.q1",
+			},
+		},
+
+		{
+			testName: "Open-ended star range.",
+			orgID:    orgId1,
+			pattern:  "*{3,}",
+			expect: []string{
+				"top.europe.sales",
+				"top.europe.archive",
+				"top.asia.sales_2024",
+				"top.asia.sales_2024.q1",
+			},
+		},
+
+		{
+			testName: "Star with only an upper bound.",
+			orgID:    orgId1,
+			pattern:  "*{,1}.asia",
+			expect:   []string{"top.asia"},
+		},
+
+		{
+			testName: "Alternatives.",
+			orgID:    orgId1,
+			pattern:  "top.europe|asia",
+			expect:   []string{"top.europe", "top.asia"},
+		},
+
+		{
+			testName: "Negation.",
+			orgID:    orgId1,
+			pattern:  "top.europe.!archive",
+			expect:   []string{"top.europe.sales"},
+		},
+
+		{
+			testName: "Negated alternatives.",
+			orgID:    orgId1,
+			pattern:  "top.!europe|asia.*",
+			expect:   []string{},
+		},
+
+		{
+			testName: "Prefix.",
+			orgID:    orgId1,
+			pattern:  "*.sales*.*",
+			expect: []string{
+				"top.europe.sales",
+				"top.asia.sales_2024",
+				"top.asia.sales_2024.q1",
+			},
+		},
+
+		{
+			testName: "Only the requested org.",
+			orgID:    orgId2,
+			pattern:  "*.sales",
+			expect:   []string{"top.sales"},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Empty pattern.",
+			orgID:       orgId1,
+			pattern:     "",
+			expectError: folder.ErrInvalidPattern,
+		},
+
+		{
+			testName:    "Empty level.",
+			orgID:       orgId1,
+			pattern:     "top..sales",
+			expectError: folder.ErrInvalidPattern,
+		},
+
+		{
+			testName:    "Empty alternative.",
+			orgID:       orgId1,
+			pattern:     "top.europe|",
+			expectError: folder.ErrInvalidPattern,
+		},
+
+		{
+			testName:    "Unclosed quantifier.",
+			orgID:       orgId1,
+			pattern:     "top.*{1",
+			expectError: folder.ErrInvalidPattern,
+		},
+
+		{
+			testName:    "Reversed quantifier.",
+			orgID:       orgId1,
+			pattern:     "top.*{3,1}",
+			expectError: folder.ErrInvalidPattern,
+		},
+
+		{
+			testName:    "Illegal characters.",
+			orgID:       orgId1,
+			pattern:     "top.eu rope",
+			expectError: folder.ErrInvalidPattern,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			result, err := f.Match(tc.orgID, tc.pattern)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+				return
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+
+			paths := []string{}
+			for _, f := range result {
+				paths = append(paths, f.Paths)
+			}
+
+			assert.Equal(t, tc.expect, paths, "unexpected result")
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package folder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// a parsed ltree ltxtquery, e.g. "Europe & Sales* & !archive@"
+type ltxtquery interface {
+	// reports whether a path made of labels satisfies the query.
+	eval(labels []string) bool
+}
+
+// matches if any label equals text.
+type ltxtWord struct {
+	text string
+	// matches any label starting with text
+	prefix bool
+	// ignores case when comparing
+	caseless bool
+}
+
+type ltxtNot struct{ operand ltxtquery }
+type ltxtAnd struct{ left, right ltxtquery }
+type ltxtOr struct{ left, right ltxtquery }
+
+func (w ltxtWord) eval(labels []string) bool {
+	for _, label := range labels {
+		if w.matchLabel(label) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w ltxtWord) matchLabel(label string) bool {
+	text := w.text
+	if w.caseless {
+		label, text = strings.ToLower(label), strings.ToLower(text)
+	}
+
+	if w.prefix {
+		return strings.HasPrefix(label, text)
+	}
+
+	return label == text
+}
+
+func (n ltxtNot) eval(labels []string) bool { return !n.operand.eval(labels) }
+func (a ltxtAnd) eval(labels []string) bool { return a.left.eval(labels) && a.right.eval(labels) }
+func (o ltxtOr) eval(labels []string) bool  { return o.left.eval(labels) || o.right.eval(labels) }
+
+func (d *driver) MatchText(orgID uuid.UUID, query string) ([]Folder, error) {
+	q, err := parseLtxtquery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []Folder{}
+	for _, node := range d.nodes {
+		folder := node.Folder
+
+		if folder.OrgId == orgID && q.eval(strings.Split(folder.Paths, ".")) {
+			res = append(res, *folder)
+		}
+	}
+
+	return res, nil
+}
+
+// recursive descent parser. from loosest to tightest binding:
+//
+//	or   = and { "|" and }
+//	and  = not { "&" not }
+//	not  = "!" not | "(" or ")" | word
+//	word = label [ "*" | "@" ]...
+type ltxtParser struct {
+	input string
+	pos   int
+}
+
+func parseLtxtquery(query string) (ltxtquery, error) {
+	p := &ltxtParser{input: query}
+
+	q, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek() != 0 {
+		return nil, p.errorf("unexpected %q", p.peek())
+	}
+
+	return q, nil
+}
+
+// returns the next non-space byte without consuming it, or 0 at the end.
+func (p *ltxtParser) peek() byte {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+
+	if p.pos == len(p.input) {
+		return 0
+	}
+
+	return p.input[p.pos]
+}
+
+func (p *ltxtParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrInvalidTextQuery, fmt.Sprintf(format, args...), p.pos)
+}
+
+func (p *ltxtParser) parseOr() (ltxtquery, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == '|' {
+		p.pos++
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = ltxtOr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *ltxtParser) parseAnd() (ltxtquery, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == '&' {
+		p.pos++
+
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		left = ltxtAnd{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *ltxtParser) parseNot() (ltxtquery, error) {
+	switch p.peek() {
+	case '!':
+		p.pos++
+
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return ltxtNot{operand: operand}, nil
+
+	case '(':
+		p.pos++
+
+		q, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek() != ')' {
+			return nil, p.errorf("missing )")
+		}
+		p.pos++
+
+		return q, nil
+	}
+
+	return p.parseWord()
+}
+
+func (p *ltxtParser) parseWord() (ltxtquery, error) {
+	p.peek()
+
+	start := p.pos
+	for p.pos < len(p.input) && isLabelRune(rune(p.input[p.pos])) {
+		p.pos++
+	}
+
+	if p.pos == start {
+		if p.pos == len(p.input) {
+			return nil, p.errorf("expected a word")
+		}
+
+		return nil, p.errorf("unexpected %q", p.input[p.pos])
+	}
+
+	word := ltxtWord{text: p.input[start:p.pos]}
+
+	for p.pos < len(p.input) {
+		switch p.input[p.pos] {
+		case '*':
+			word.prefix = true
+		case '@':
+			word.caseless = true
+		default:
+			return word, nil
+		}
+
+		p.pos++
+	}
+
+	return word, nil
+}
@@ -0,0 +1,198 @@
+package folder_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_folder_MatchText(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "Europe", OrgId: orgId1, Paths: "Europe"},
+		{Name: "Sales", OrgId: orgId1, Paths: "Europe.Sales"},
+		{Name: "SalesOps", OrgId: orgId1, Paths: "Europe.SalesOps"},
+		{Name: "archive", OrgId: orgId1, Paths: "Europe.Sales.archive"},
+		{Name: "Asia", OrgId: orgId1, Paths: "Asia"},
+		{Name: "Sales", OrgId: orgId1, Paths: "Asia.Sales"},
+		{Name: "Europe", OrgId: orgId2, Paths: "Europe"},
+	}
+
+	tests := [...]struct {
+		testName    string
+		orgID       uuid.UUID
+		query       string
+		expect      []string
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName: "Single word matches any label.",
+			orgID:    orgId1,
+			query:    "Sales",
+			expect:   []string{"Europe.Sales", "Europe.Sales.archive", "Asia.Sales"},
+		},
+
+		{
+			testName: "And with prefix.",
+			orgID:    orgId1,
+			query:    "Europe & Sales*",
+			expect:   []string{"Europe.Sales", "Europe.SalesOps", "Europe.Sales.archive"},
+		},
+
+		{
+			testName: "Not.",
+			orgID:    orgId1,
+			query:    "Sales & !archive",
+			expect:   []string{"Europe.Sales", "Asia.Sales"},
+		},
+
+		{
+			testName: "Not on its own.",
+			orgID:    orgId1,
+			query:    "!Europe",
+			expect:   []string{"Asia", "Asia.Sales"},
+		},
+
+		{
+			testName: "Or binds looser than and.",
+			orgID:    orgId1,
+			query:    "Asia | Europe & archive",
+			expect:   []string{"Europe.Sales.archive", "Asia", "Asia.Sales"},
+		},
+
+		{
+			testName: "Parentheses.",
+			orgID:    orgId1,
+			query:    "(Asia | Europe) & !Sales*",
+			expect:   []string{"Europe", "Asia"},
+		},
+
+		{
+			testName: "Case-insensitive word.",
+			orgID:    orgId1,
+			query:    "ARCHIVE@",
+			expect:   []string{"Europe.Sales.archive"},
+		},
+
+		{
+			testName: "Case-insensitive prefix.",
+			orgID:    orgId1,
+			query:    "salesop*@",
+			expect:   []string{"Europe.SalesOps"},
+		},
+
+		{
+			testName: "Words are case-sensitive by default.",
+			orgID:    orgId1,
+			query:    "europe",
+			expect:   []string{},
+		},
+
+		{
+			testName: "Only the requested org.",
+			orgID:    orgId2,
+			query:    "Europe",
+			expect:   []string{"Europe"},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Empty query.",
+			orgID:       orgId1,
+			query:       "",
+			expectError: folder.ErrInvalidTextQuery,
+		},
+
+		{
+			testName:    "Dangling operator.",
+			orgID:       orgId1,
+			query:       "Europe &",
+			expectError: folder.ErrInvalidTextQuery,
+		},
+
+		{
+			testName:    "Missing operator.",
+			orgID:       orgId1,
+			query:       "Europe Sales",
+			expectError: folder.ErrInvalidTextQuery,
+		},
+
+		{
+			testName:    "Unbalanced parentheses.",
+			orgID:       orgId1,
+			query:       "(Europe | Asia",
+			expectError: folder.ErrInvalidTextQuery,
+		},
+
+		{
+			testName:    "Illegal character.",
+			orgID:       orgId1,
+			query:       "Europe & Sa.les",
+			expectError: folder.ErrInvalidTextQuery,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			result, err := f.MatchText(tc.orgID, tc.query)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+				return
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+
+			paths := []string{}
+			for _, f := range result {
+				paths = append(paths, f.Paths)
+			}
+
+			assert.Equal(t, tc.expect, paths, "unexpected result")
+		})
+	}
+}
+
+func Test_folder_MatchText_SampleData(t *testing.T) {
+	t.Parallel()
+
+	orgID := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	f, err := folder.NewDriver(folder.GetSampleData())
+	assert.NoError(t, err, "unexpected error")
+
+	all, err := f.GetFoldersByOrgID(orgID)
+	assert.NoError(t, err, "unexpected error")
+
+	result, err := f.MatchText(orgID, "nearby-secret & !fast*")
+	assert.NoError(t, err, "unexpected error")
+	assert.NotEmpty(t, result, "expected some matches in the sample data")
+
+	// every folder is either matched or fails the query, never both.
+	for _, folder := range all {
+		labels := strings.Split(folder.Paths, ".")
+		satisfies := slices.Contains(labels, "nearby-secret") &&
+			!slices.ContainsFunc(labels, func(l string) bool { return strings.HasPrefix(l, "fast") })
+
+		assert.Equal(t, satisfies, slices.Contains(result, folder), "wrong answer for %s", folder.Paths)
+	}
+}
@@ -1,78 +1,292 @@
 package folder
 
 import (
+	"slices"
 	"strings"
+
+	"github.com/gofrs/uuid"
 )
 
 func (d *driver) MoveFolder(
 	name string,
 	dst string,
 ) ([]Folder, error) {
+	srcNode, dstNode, err := d.prepareMove(name, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.moveNode(srcNode, dstNode), nil
+}
+
+// finds and validates the source and destination of MoveFolder.
+func (d *driver) prepareMove(name string, dst string) (*FolderNode, *FolderNode, error) {
 	if name == "" || dst == "" {
-		return nil, ErrInvalidArguments
+		return nil, nil, ErrInvalidArguments
+	}
+
+	srcNode, err := d.lookup(name)
+	if err == ErrFolderDoesNotExist {
+		return nil, nil, ErrSourceDoesNotExist
+	} else if err != nil {
+		return nil, nil, err
 	}
 
-	if name == dst {
-		return nil, ErrMoveToSource
+	// a destination in the source's org wins over same-named folders in other orgs.
+	dstNode, err := d.lookupInOrg(srcNode.Folder.OrgId, dst)
+	if err == ErrFolderDoesNotExist {
+		return nil, nil, ErrDestDoesNotExist
+	} else if err == ErrFolderDoesNotExistInOrg {
+		return nil, nil, ErrMoveToDifferentOrg
+	} else if err != nil {
+		return nil, nil, err
 	}
 
-	srcNode, srcExists := d.nameToNode[name]
-	dstNode, dstExists := d.nameToNode[dst]
+	if err := d.validateMove(srcNode, dstNode); err != nil {
+		return nil, nil, err
+	}
+
+	return srcNode, dstNode, nil
+}
 
-	if !srcExists {
+func (d *driver) MoveFolderByID(
+	id uuid.UUID,
+	dstID uuid.UUID,
+) ([]Folder, error) {
+	srcNode, err := d.lookupID(id)
+	if err != nil {
 		return nil, ErrSourceDoesNotExist
 	}
 
-	if !dstExists {
+	dstNode, err := d.lookupID(dstID)
+	if err != nil {
 		return nil, ErrDestDoesNotExist
 	}
 
+	if err := d.validateMove(srcNode, dstNode); err != nil {
+		return nil, err
+	}
+
+	return d.moveNode(srcNode, dstNode), nil
+}
+
+func (d *driver) MoveFolderToRoot(name string) ([]Folder, error) {
+	srcNode, err := d.prepareMoveToRoot(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.moveNode(srcNode, nil), nil
+}
+
+// finds and validates the source of MoveFolderToRoot.
+func (d *driver) prepareMoveToRoot(name string) (*FolderNode, error) {
+	if name == "" {
+		return nil, ErrInvalidArguments
+	}
+
+	srcNode, err := d.lookup(name)
+	if err == ErrFolderDoesNotExist {
+		return nil, ErrSourceDoesNotExist
+	} else if err != nil {
+		return nil, err
+	}
+
+	srcFolder := srcNode.Folder
+
+	if !strings.Contains(srcFolder.Paths, ".") {
+		return nil, ErrAlreadyRoot
+	}
+
+	if d.folderAt(srcFolder.OrgId, srcFolder.Name) != nil {
+		return nil, ErrFolderAlreadyExists
+	}
+
+	return srcNode, nil
+}
+
+func (d *driver) MoveFolderInOrg(
+	orgID uuid.UUID,
+	name string,
+	dst string,
+) ([]Folder, error) {
+	if name == "" || dst == "" {
+		return nil, ErrInvalidArguments
+	}
+
+	srcNode, dstNode, err := d.lookupMoveInOrg(orgID, name, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.validateMove(srcNode, dstNode); err != nil {
+		return nil, err
+	}
+
+	return foldersInOrg(d.moveNode(srcNode, dstNode), orgID), nil
+}
+
+// finds the source and destination of a move, both of which must be in orgID.
+func (d *driver) lookupMoveInOrg(orgID uuid.UUID, name string, dst string) (*FolderNode, *FolderNode, error) {
+	srcNode, err := d.lookupInOrg(orgID, name)
+	if err == ErrFolderDoesNotExist {
+		return nil, nil, ErrSourceDoesNotExist
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	dstNode, err := d.lookupInOrg(orgID, dst)
+	if err == ErrFolderDoesNotExist {
+		return nil, nil, ErrDestDoesNotExist
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	return srcNode, dstNode, nil
+}
+
+func foldersInOrg(folders []Folder, orgID uuid.UUID) []Folder {
+	res := []Folder{}
+	for _, folder := range folders {
+		if folder.OrgId == orgID {
+			res = append(res, folder)
+		}
+	}
+
+	return res
+}
+
+// checks that srcNode can be moved under dstNode.
+func (d *driver) validateMove(srcNode *FolderNode, dstNode *FolderNode) error {
 	srcFolder, dstFolder := srcNode.Folder, dstNode.Folder
 
+	if srcNode == dstNode {
+		return ErrMoveToSource
+	}
+
 	if srcFolder.OrgId != dstFolder.OrgId {
-		return nil, ErrMoveToDifferentOrg
+		return ErrMoveToDifferentOrg
 	}
 
 	if isDescendant(srcFolder.Paths, dstFolder.Paths) {
-		return nil, ErrMoveToDescendant
+		return ErrMoveToDescendant
 	}
 
-	res, err := moveFolderAndChildren(d, srcFolder, dstFolder)
-	if err != nil {
-		return nil, err
+	// names only need to be unique among siblings.
+	occupant := d.folderAt(srcFolder.OrgId, dstFolder.Paths+"."+srcFolder.Name)
+	if occupant != nil && occupant != srcNode {
+		return ErrFolderAlreadyExists
+	}
+
+	return nil
+}
+
+// moves srcNode under dstNode, or to the root when dstNode is nil.
+// the move is applied to the tree only if the driver is stateful.
+func (d *driver) moveNode(srcNode *FolderNode, dstNode *FolderNode) []Folder {
+	if d.stateful {
+		applyMove(srcNode, dstNode)
+		d.version++
+
+		return d.allFolders()
 	}
 
-	return res, nil
+	return moveFolderAndChildren(d, srcNode, dstNode, appendChild)
+}
+
+// returns the path srcNode gets when moved under dstNode, or to the root when dstNode is nil.
+func movedPath(srcNode *FolderNode, dstNode *FolderNode) string {
+	if dstNode == nil {
+		return srcNode.Folder.Name
+	}
+
+	return dstNode.Folder.Paths + "." + srcNode.Folder.Name
+}
+
+// moves srcNode under dstNode in the tree, or to the root when dstNode is nil.
+func applyMove(srcNode *FolderNode, dstNode *FolderNode) {
+	newPrefix := movedPath(srcNode, dstNode)
+
+	detachNode(srcNode)
+	if dstNode != nil {
+		attachNode(srcNode, dstNode)
+	} else {
+		// roots keep input order
+		srcNode.Folder.Position = 0
+	}
+	rebasePaths(srcNode, newPrefix)
 }
 
 // Copy all the folders and update the necessary paths to move a folder.
 // O(n) complexity as we have to return a copy of all the folders anyway.
-func moveFolderAndChildren(d *driver, srcFolder *Folder, dstFolder *Folder) ([]Folder, error) {
+// index is where srcNode goes among dstNode's children, or appendChild.
+func moveFolderAndChildren(d *driver, srcNode *FolderNode, dstNode *FolderNode, index int) []Folder {
 	res := []Folder{}
-	newPrefix := dstFolder.Paths + "." + srcFolder.Name
+	newPrefix := movedPath(srcNode, dstNode)
+	positions := plannedPositions(srcNode, dstNode, index)
 
-	srcSegments := strings.Split(srcFolder.Paths, ".")
+	// names can repeat across orgs, so the subtree is found through the tree
+	// rather than by matching path prefixes.
+	moved := map[*FolderNode]bool{}
+	walkSubtree(srcNode, func(n *FolderNode) {
+		moved[n] = true
+	})
 
-	for _, name := range d.folderNames {
-		node, ok := d.nameToNode[name]
-		if !ok {
-			return nil, ErrUnexpectedError
-		}
+	srcPaths := srcNode.Folder.Paths
 
+	for _, node := range d.nodes {
 		folder := *node.Folder
 
-		if folder.Paths == srcFolder.Paths {
-			folder.Paths = newPrefix
-		} else if isDescendant(srcFolder.Paths, folder.Paths) {
-			folderSegments := strings.Split(folder.Paths, ".")
+		if moved[node] {
+			folder.Paths = replacePathPrefix(folder.Paths, srcPaths, newPrefix)
+		}
 
-			folder.Paths = newPrefix + "." + strings.Join(folderSegments[len(srcSegments):], ".")
+		if position, ok := positions[node]; ok {
+			folder.Position = position
 		}
 
 		res = append(res, folder)
 	}
 
-	return res, nil
+	return res
+}
+
+// index for moves that add the folder after its new siblings, as attachNode does.
+const appendChild = -1
+
+// works out the positions that moving srcNode to index under dstNode would
+// give the folders involved, the same way detachNode renumbers them and
+// attachNode or insertNode place them.
+func plannedPositions(srcNode *FolderNode, dstNode *FolderNode, index int) map[*FolderNode]int {
+	positions := map[*FolderNode]int{srcNode: 0}
+
+	number := func(siblings []*FolderNode) {
+		for i, sibling := range siblings {
+			positions[sibling] = i + 1
+		}
+	}
+
+	if srcNode.Parent != nil {
+		oldSiblings := slices.DeleteFunc(slices.Clone(srcNode.Parent.Children), func(n *FolderNode) bool {
+			return n == srcNode
+		})
+		if isOrdered(oldSiblings) {
+			number(oldSiblings)
+		}
+	}
+
+	if dstNode != nil {
+		newSiblings := slices.DeleteFunc(slices.Clone(dstNode.Children), func(n *FolderNode) bool {
+			return n == srcNode
+		})
+		if index != appendChild {
+			number(slices.Insert(newSiblings, index, srcNode))
+		} else if isOrdered(newSiblings) {
+			number(append(newSiblings, srcNode))
+		}
+	}
+
+	return positions
 }
 
 // determines if childPath is a descendant of parentPath
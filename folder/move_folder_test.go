@@ -157,8 +157,7 @@ func Test_folder_MoveFolder(t *testing.T) {
 			expectError: true,
 		},
 
-		// (this is assuming that dst cannot be empty)
-		// Should it move to the root instead?
+		// (moving to the root is done through MoveFolderToRoot)
 		{
 			testName: "Destination cannot be empty.",
 			src:      "alpha",
@@ -264,3 +263,562 @@ func Test_folder_MoveFolder(t *testing.T) {
 	}
 
 }
+
+func Test_folder_MoveFolder_Stateful(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: orgId1, Paths: "alpha.delta"},
+		{Name: "golf", OrgId: orgId1, Paths: "golf"},
+	}
+
+	f, err := folder.NewDriver(folders, folder.WithStatefulMoves())
+	assert.NoError(t, err, "unexpected error")
+
+	_, err = f.MoveFolder("bravo", "delta")
+	assert.NoError(t, err, "unexpected error")
+
+	// the second move only works if the first one was applied to the tree.
+	result, err := f.MoveFolder("delta", "golf")
+	assert.NoError(t, err, "unexpected error")
+
+	expect := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "golf.delta.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "golf.delta.bravo.charlie"},
+		{Name: "delta", OrgId: orgId1, Paths: "golf.delta"},
+		{Name: "golf", OrgId: orgId1, Paths: "golf"},
+	}
+	assert.Equal(t, expect, result, "unexpected result")
+
+	all, err := f.GetFoldersByOrgID(orgId1)
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, expect, all, "unexpected result")
+
+	children, err := f.GetAllChildFolders(orgId1, "golf")
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, []folder.Folder{
+		{Name: "delta", OrgId: orgId1, Paths: "golf.delta"},
+		{Name: "bravo", OrgId: orgId1, Paths: "golf.delta.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "golf.delta.bravo.charlie"},
+	}, children, "unexpected result")
+
+	children, err = f.GetAllChildFolders(orgId1, "alpha")
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, []folder.Folder{}, children, "unexpected result")
+
+	_, err = f.MoveFolder("golf", "charlie")
+	assert.ErrorIs(t, err, folder.ErrMoveToDescendant)
+
+	// the caller's slice is left untouched.
+	assert.Equal(t, "alpha.bravo", folders[1].Paths, "input was mutated")
+}
+
+func Test_folder_MoveFolder_Stateless(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	f, err := folder.NewDriver([]folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "golf", OrgId: orgId1, Paths: "golf"},
+	})
+	assert.NoError(t, err, "unexpected error")
+
+	_, err = f.MoveFolder("bravo", "golf")
+	assert.NoError(t, err, "unexpected error")
+
+	children, err := f.GetAllChildFolders(orgId1, "alpha")
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, []folder.Folder{
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+	}, children, "unexpected result")
+}
+
+func Test_folder_MoveFolderToRoot(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: orgId1, Paths: "alpha.delta"},
+	}
+
+	tests := [...]struct {
+		testName    string
+		src         string
+		expect      []folder.Folder
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName: "Promote a nested folder with children.",
+			src:      "bravo",
+			expect: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "bravo"},
+				{Name: "charlie", OrgId: orgId1, Paths: "bravo.charlie"},
+				{Name: "delta", OrgId: orgId1, Paths: "alpha.delta"},
+			},
+		},
+
+		{
+			testName: "Promote a deeply nested leaf.",
+			src:      "charlie",
+			expect: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+				{Name: "charlie", OrgId: orgId1, Paths: "charlie"},
+				{Name: "delta", OrgId: orgId1, Paths: "alpha.delta"},
+			},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Source cannot be empty.",
+			src:         "",
+			expectError: folder.ErrInvalidArguments,
+		},
+
+		{
+			testName:    "Source doesnt exist.",
+			src:         "x",
+			expectError: folder.ErrSourceDoesNotExist,
+		},
+
+		{
+			testName:    "Already a root.",
+			src:         "alpha",
+			expectError: folder.ErrAlreadyRoot,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		for _, stateful := range []bool{false, true} {
+			testName := tc.testName
+			opts := []folder.DriverOption{}
+			if stateful {
+				testName += " (stateful)"
+				opts = append(opts, folder.WithStatefulMoves())
+			}
+
+			t.Run(testName, func(t *testing.T) {
+				t.Parallel()
+
+				f, err := folder.NewDriver(folders, opts...)
+				assert.NoError(t, err, "unexpected error")
+
+				result, err := f.MoveFolderToRoot(tc.src)
+
+				if tc.expectError != nil {
+					assert.ErrorIs(t, err, tc.expectError, "expected error")
+					return
+				} else {
+					assert.NoError(t, err, "unexpected error")
+				}
+
+				assert.Equal(t, tc.expect, result, "unexpected result")
+			})
+		}
+	}
+}
+
+func Test_folder_MoveFolderToRoot_Stateful(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	f, err := folder.NewDriver([]folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+	}, folder.WithStatefulMoves())
+	assert.NoError(t, err, "unexpected error")
+
+	_, err = f.MoveFolderToRoot("bravo")
+	assert.NoError(t, err, "unexpected error")
+
+	children, err := f.GetAllChildFolders(orgId1, "alpha")
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, []folder.Folder{}, children, "unexpected result")
+
+	_, err = f.MoveFolderToRoot("bravo")
+	assert.ErrorIs(t, err, folder.ErrAlreadyRoot)
+
+	// a promoted root can be moved back under another folder.
+	result, err := f.MoveFolder("bravo", "alpha")
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+	}, result, "unexpected result")
+}
+
+func Test_folder_MoveFolderInOrg(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: orgId1, Paths: "delta"},
+		{Name: "foxtrot", OrgId: orgId2, Paths: "foxtrot"},
+		{Name: "golf", OrgId: orgId2, Paths: "foxtrot.golf"},
+	}
+
+	tests := [...]struct {
+		testName    string
+		orgID       uuid.UUID
+		src         string
+		dst         string
+		expect      []folder.Folder
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName: "Only returns the caller's folders.",
+			orgID:    orgId1,
+			src:      "bravo",
+			dst:      "delta",
+			expect: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "delta.bravo"},
+				{Name: "charlie", OrgId: orgId1, Paths: "delta.bravo.charlie"},
+				{Name: "delta", OrgId: orgId1, Paths: "delta"},
+			},
+		},
+
+		{
+			testName: "Moves within another org.",
+			orgID:    orgId2,
+			src:      "golf",
+			dst:      "foxtrot",
+			expect: []folder.Folder{
+				{Name: "foxtrot", OrgId: orgId2, Paths: "foxtrot"},
+				{Name: "golf", OrgId: orgId2, Paths: "foxtrot.golf"},
+			},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Source belongs to another org.",
+			orgID:       orgId1,
+			src:         "golf",
+			dst:         "delta",
+			expectError: folder.ErrFolderDoesNotExistInOrg,
+		},
+
+		{
+			testName:    "Destination belongs to another org.",
+			orgID:       orgId1,
+			src:         "bravo",
+			dst:         "foxtrot",
+			expectError: folder.ErrFolderDoesNotExistInOrg,
+		},
+
+		{
+			testName:    "Both belong to another org.",
+			orgID:       orgId1,
+			src:         "golf",
+			dst:         "foxtrot",
+			expectError: folder.ErrFolderDoesNotExistInOrg,
+		},
+
+		{
+			testName:    "Source doesnt exist.",
+			orgID:       orgId1,
+			src:         "x",
+			dst:         "delta",
+			expectError: folder.ErrSourceDoesNotExist,
+		},
+
+		{
+			testName:    "Still can't move to a descendant.",
+			orgID:       orgId1,
+			src:         "alpha",
+			dst:         "charlie",
+			expectError: folder.ErrMoveToDescendant,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			result, err := f.MoveFolderInOrg(tc.orgID, tc.src, tc.dst)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+				return
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+
+			assert.Equal(t, tc.expect, result, "unexpected result")
+		})
+	}
+}
+
+func Test_folder_MoveFolder_DuplicateNames(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "reports", OrgId: orgId1, Paths: "alpha.reports"},
+		{Name: "bravo", OrgId: orgId1, Paths: "bravo"},
+		{Name: "reports", OrgId: orgId1, Paths: "bravo.reports"},
+		{Name: "charlie", OrgId: orgId1, Paths: "charlie"},
+		{Name: "charlie", OrgId: orgId2, Paths: "charlie"},
+		{Name: "delta", OrgId: orgId2, Paths: "charlie.delta"},
+	}
+
+	tests := [...]struct {
+		testName    string
+		src         string
+		dst         string
+		expect      []folder.Folder
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName: "Destination is resolved in the source's org.",
+			src:      "delta",
+			dst:      "charlie",
+			expect: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "reports", OrgId: orgId1, Paths: "alpha.reports"},
+				{Name: "bravo", OrgId: orgId1, Paths: "bravo"},
+				{Name: "reports", OrgId: orgId1, Paths: "bravo.reports"},
+				{Name: "charlie", OrgId: orgId1, Paths: "charlie"},
+				{Name: "charlie", OrgId: orgId2, Paths: "charlie"},
+				{Name: "delta", OrgId: orgId2, Paths: "charlie.delta"},
+			},
+		},
+
+		{
+			testName: "Same-named folders in other orgs arent moved.",
+			src:      "alpha",
+			dst:      "bravo",
+			expect: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "bravo.alpha"},
+				{Name: "reports", OrgId: orgId1, Paths: "bravo.alpha.reports"},
+				{Name: "bravo", OrgId: orgId1, Paths: "bravo"},
+				{Name: "reports", OrgId: orgId1, Paths: "bravo.reports"},
+				{Name: "charlie", OrgId: orgId1, Paths: "charlie"},
+				{Name: "charlie", OrgId: orgId2, Paths: "charlie"},
+				{Name: "delta", OrgId: orgId2, Paths: "charlie.delta"},
+			},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Ambiguous source.",
+			src:         "reports",
+			dst:         "alpha",
+			expectError: folder.ErrAmbiguousFolderName,
+		},
+
+		{
+			testName:    "Ambiguous source across orgs.",
+			src:         "charlie",
+			dst:         "alpha",
+			expectError: folder.ErrAmbiguousFolderName,
+		},
+
+		{
+			testName:    "Ambiguous destination.",
+			src:         "charlie",
+			dst:         "reports",
+			expectError: folder.ErrAmbiguousFolderName,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			result, err := f.MoveFolder(tc.src, tc.dst)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+				return
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+
+			assert.Equal(t, tc.expect, result, "unexpected result")
+		})
+	}
+}
+
+func Test_folder_MoveFolderByID(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	alphaID := uuid.Must(uuid.NewV4())
+	reports1ID := uuid.Must(uuid.NewV4())
+	bravoID := uuid.Must(uuid.NewV4())
+	reports2ID := uuid.Must(uuid.NewV4())
+	foxtrotID := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{ID: alphaID, Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{ID: reports1ID, Name: "reports", OrgId: orgId1, Paths: "alpha.reports"},
+		{ID: bravoID, Name: "bravo", OrgId: orgId1, Paths: "bravo"},
+		{ID: reports2ID, Name: "reports", OrgId: orgId1, Paths: "bravo.reports"},
+		{ID: foxtrotID, Name: "foxtrot", OrgId: orgId2, Paths: "foxtrot"},
+	}
+
+	tests := [...]struct {
+		testName    string
+		src         uuid.UUID
+		dst         uuid.UUID
+		expect      []folder.Folder
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName: "Moves one of several same-named folders.",
+			src:      reports1ID,
+			dst:      reports2ID,
+			expect: []folder.Folder{
+				{ID: alphaID, Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{ID: reports1ID, Name: "reports", OrgId: orgId1, Paths: "bravo.reports.reports"},
+				{ID: bravoID, Name: "bravo", OrgId: orgId1, Paths: "bravo"},
+				{ID: reports2ID, Name: "reports", OrgId: orgId1, Paths: "bravo.reports"},
+				{ID: foxtrotID, Name: "foxtrot", OrgId: orgId2, Paths: "foxtrot"},
+			},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Clashes with a sibling of the same name.",
+			src:         reports1ID,
+			dst:         bravoID,
+			expectError: folder.ErrFolderAlreadyExists,
+		},
+
+		{
+			testName:    "Source doesnt exist.",
+			src:         uuid.Must(uuid.NewV4()),
+			dst:         bravoID,
+			expectError: folder.ErrSourceDoesNotExist,
+		},
+
+		{
+			testName:    "Destination doesnt exist.",
+			src:         alphaID,
+			dst:         uuid.Must(uuid.NewV4()),
+			expectError: folder.ErrDestDoesNotExist,
+		},
+
+		{
+			testName:    "Can't move across organisations.",
+			src:         alphaID,
+			dst:         foxtrotID,
+			expectError: folder.ErrMoveToDifferentOrg,
+		},
+
+		{
+			testName:    "Can't move a folder to itself.",
+			src:         alphaID,
+			dst:         alphaID,
+			expectError: folder.ErrMoveToSource,
+		},
+
+		{
+			testName:    "Can't move a folder to a child of itself.",
+			src:         alphaID,
+			dst:         reports1ID,
+			expectError: folder.ErrMoveToDescendant,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			result, err := f.MoveFolderByID(tc.src, tc.dst)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+				return
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+
+			assert.Equal(t, tc.expect, result, "unexpected result")
+		})
+	}
+}
+
+func Test_folder_MoveFolderByID_SurvivesRename(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	alphaID := uuid.Must(uuid.NewV4())
+	bravoID := uuid.Must(uuid.NewV4())
+
+	f, err := folder.NewDriver([]folder.Folder{
+		{ID: alphaID, Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{ID: bravoID, Name: "bravo", OrgId: orgId1, Paths: "bravo"},
+	}, folder.WithStatefulMoves())
+	assert.NoError(t, err, "unexpected error")
+
+	_, err = f.RenameFolder(orgId1, "alpha", "apex")
+	assert.NoError(t, err, "unexpected error")
+
+	result, err := f.MoveFolderByID(alphaID, bravoID)
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, []folder.Folder{
+		{ID: alphaID, Name: "apex", OrgId: orgId1, Paths: "bravo.apex"},
+		{ID: bravoID, Name: "bravo", OrgId: orgId1, Paths: "bravo"},
+	}, result, "unexpected result")
+}
@@ -0,0 +1,226 @@
+package folder
+
+import (
+	"cmp"
+	"container/heap"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// SortOrder decides the order folders are paged through.
+type SortOrder int
+
+const (
+	// SortByPath orders folders as they appear in the tree: each folder is
+	// followed by its descendants, with paths compared label by label.
+	SortByPath SortOrder = iota
+	// SortByName orders folders by name, then by path.
+	SortByName
+	// SortByDepth orders shallower folders first, then by path.
+	SortByDepth
+)
+
+// PageOptions selects one page of a listing.
+type PageOptions struct {
+	// maximum number of folders in the page, must be at least 1
+	Size int
+	// the NextCursor of the previous page, empty for the first page
+	Cursor string
+	Sort   SortOrder
+}
+
+// Page is one page of a listing.
+type Page struct {
+	Folders []Folder
+	// pass this as PageOptions.Cursor to get the next page, empty on the last page
+	NextCursor string
+}
+
+// position of a folder in a listing. paths are unique within an org, so
+// every sort order ends with the path to keep keys distinct.
+type pageKey struct {
+	Name  string `json:"n,omitempty"`
+	Depth int    `json:"d,omitempty"`
+	Paths string `json:"p"`
+}
+
+// what a cursor encodes: the last key of the previous page.
+type pageCursor struct {
+	Sort SortOrder `json:"s"`
+	Key  pageKey   `json:"k"`
+}
+
+func (d *driver) GetFoldersByOrgIDPage(orgID uuid.UUID, opts PageOptions) (Page, error) {
+	return paginate(opts, func(yield func(*FolderNode)) {
+		for _, node := range d.nodes {
+			if node.Folder.OrgId == orgID {
+				yield(node)
+			}
+		}
+	})
+}
+
+func (d *driver) GetAllChildFoldersPage(orgID uuid.UUID, name string, opts PageOptions) (Page, error) {
+	node, err := d.lookupInOrg(orgID, name)
+	if err != nil {
+		return Page{}, err
+	}
+
+	return paginate(opts, func(yield func(*FolderNode)) {
+		walkSubtree(node, func(n *FolderNode) {
+			if n != node && n.Folder.OrgId == orgID {
+				yield(n)
+			}
+		})
+	})
+}
+
+// returns the page of folders from scan that comes after the cursor. only the
+// best opts.Size+1 candidates are kept while scanning, the extra one telling
+// us whether there is a next page.
+func paginate(opts PageOptions, scan func(yield func(*FolderNode))) (Page, error) {
+	if opts.Size < 1 {
+		return Page{}, ErrInvalidPageSize
+	}
+
+	if opts.Sort < SortByPath || opts.Sort > SortByDepth {
+		return Page{}, ErrUnknownSortOrder
+	}
+
+	var after *pageKey
+	if opts.Cursor != "" {
+		cursor, err := decodeCursor(opts.Cursor)
+		if err != nil || cursor.Sort != opts.Sort {
+			return Page{}, ErrInvalidCursor
+		}
+
+		after = &cursor.Key
+	}
+
+	h := &pageHeap{sort: opts.Sort}
+	scan(func(node *FolderNode) {
+		key := keyOf(node.Folder)
+		if after != nil && compareKeys(opts.Sort, key, *after) <= 0 {
+			return
+		}
+
+		heap.Push(h, pageEntry{key: key, folder: node.Folder})
+		if h.Len() > opts.Size+1 {
+			heap.Pop(h)
+		}
+	})
+
+	entries := h.entries
+	slices.SortFunc(entries, func(a, b pageEntry) int {
+		return compareKeys(opts.Sort, a.key, b.key)
+	})
+
+	page := Page{Folders: []Folder{}}
+	if len(entries) > opts.Size {
+		entries = entries[:opts.Size]
+		page.NextCursor = encodeCursor(pageCursor{Sort: opts.Sort, Key: entries[len(entries)-1].key})
+	}
+
+	for _, entry := range entries {
+		page.Folders = append(page.Folders, *entry.folder)
+	}
+
+	return page, nil
+}
+
+func keyOf(folder *Folder) pageKey {
+	return pageKey{
+		Name:  folder.Name,
+		Depth: strings.Count(folder.Paths, "."),
+		Paths: folder.Paths,
+	}
+}
+
+func compareKeys(sort SortOrder, a pageKey, b pageKey) int {
+	switch sort {
+	case SortByName:
+		return cmp.Or(strings.Compare(a.Name, b.Name), comparePaths(a.Paths, b.Paths))
+	case SortByDepth:
+		return cmp.Or(cmp.Compare(a.Depth, b.Depth), comparePaths(a.Paths, b.Paths))
+	default:
+		return comparePaths(a.Paths, b.Paths)
+	}
+}
+
+// compares ltree paths label by label, so a folder sorts directly before its
+// descendants.
+func comparePaths(a string, b string) int {
+	for {
+		labelA, restA, moreA := strings.Cut(a, ".")
+		labelB, restB, moreB := strings.Cut(b, ".")
+
+		if c := strings.Compare(labelA, labelB); c != 0 {
+			return c
+		}
+
+		if !moreA || !moreB {
+			return cmp.Compare(boolToInt(moreA), boolToInt(moreB))
+		}
+
+		a, b = restA, restB
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+func encodeCursor(cursor pageCursor) string {
+	b, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (pageCursor, error) {
+	cursor := pageCursor{}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor, err
+	}
+
+	err = json.Unmarshal(b, &cursor)
+
+	return cursor, err
+}
+
+type pageEntry struct {
+	key    pageKey
+	folder *Folder
+}
+
+// max-heap on the sort order, so the entry that would come last is popped first.
+type pageHeap struct {
+	sort    SortOrder
+	entries []pageEntry
+}
+
+func (h *pageHeap) Len() int { return len(h.entries) }
+
+func (h *pageHeap) Less(i, j int) bool {
+	return compareKeys(h.sort, h.entries[i].key, h.entries[j].key) > 0
+}
+
+func (h *pageHeap) Swap(i, j int) { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+
+func (h *pageHeap) Push(x any) { h.entries = append(h.entries, x.(pageEntry)) }
+
+func (h *pageHeap) Pop() any {
+	last := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+
+	return last
+}
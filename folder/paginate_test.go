@@ -0,0 +1,206 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_folder_Paginate(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "delta", OrgId: orgId1, Paths: "delta"},
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "zulu", OrgId: orgId1, Paths: "alpha.zulu"},
+		{Name: "alpha-beta", OrgId: orgId1, Paths: "alpha-beta"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+		{Name: "echo", OrgId: orgId2, Paths: "echo"},
+	}
+
+	tests := [...]struct {
+		testName string
+		// empty lists the whole org
+		name   string
+		sort   folder.SortOrder
+		size   int
+		expect [][]string
+	}{
+		{
+			testName: "Org by path.",
+			sort:     folder.SortByPath,
+			size:     2,
+			expect: [][]string{
+				{"alpha", "bravo"},
+				{"charlie", "zulu"},
+				{"alpha-beta", "delta"},
+			},
+		},
+
+		{
+			testName: "Org by name.",
+			sort:     folder.SortByName,
+			size:     4,
+			expect: [][]string{
+				{"alpha", "alpha-beta", "bravo", "charlie"},
+				{"delta", "zulu"},
+			},
+		},
+
+		{
+			testName: "Org by depth.",
+			sort:     folder.SortByDepth,
+			size:     5,
+			expect: [][]string{
+				{"alpha", "alpha-beta", "delta", "bravo", "zulu"},
+				{"charlie"},
+			},
+		},
+
+		{
+			testName: "Children by path.",
+			name:     "alpha",
+			sort:     folder.SortByPath,
+			size:     2,
+			expect: [][]string{
+				{"bravo", "charlie"},
+				{"zulu"},
+			},
+		},
+
+		{
+			testName: "Page bigger than listing.",
+			name:     "bravo",
+			sort:     folder.SortByName,
+			size:     10,
+			expect: [][]string{
+				{"charlie"},
+			},
+		},
+
+		{
+			testName: "Empty listing.",
+			name:     "zulu",
+			sort:     folder.SortByPath,
+			size:     10,
+			expect: [][]string{
+				{},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			pages := [][]string{}
+			opts := folder.PageOptions{Size: tc.size, Sort: tc.sort}
+			for {
+				var page folder.Page
+				if tc.name == "" {
+					page, err = f.GetFoldersByOrgIDPage(orgId1, opts)
+				} else {
+					page, err = f.GetAllChildFoldersPage(orgId1, tc.name, opts)
+				}
+				assert.NoError(t, err, "unexpected error")
+
+				names := []string{}
+				for _, f := range page.Folders {
+					names = append(names, f.Name)
+				}
+				pages = append(pages, names)
+
+				if page.NextCursor == "" || len(pages) > len(tc.expect) {
+					break
+				}
+				opts.Cursor = page.NextCursor
+			}
+
+			assert.Equal(t, tc.expect, pages, "unexpected pages")
+		})
+	}
+}
+
+func Test_folder_Paginate_Errors(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.charlie"},
+	}
+
+	f, err := folder.NewDriver(folders)
+	assert.NoError(t, err, "unexpected error")
+
+	page, err := f.GetFoldersByOrgIDPage(orgId1, folder.PageOptions{Size: 1, Sort: folder.SortByPath})
+	assert.NoError(t, err, "unexpected error")
+
+	tests := [...]struct {
+		testName    string
+		name        string
+		opts        folder.PageOptions
+		expectError error
+	}{
+		{
+			testName:    "Zero page size.",
+			opts:        folder.PageOptions{Size: 0},
+			expectError: folder.ErrInvalidPageSize,
+		},
+
+		{
+			testName:    "Unknown sort order.",
+			opts:        folder.PageOptions{Size: 1, Sort: folder.SortOrder(99)},
+			expectError: folder.ErrUnknownSortOrder,
+		},
+
+		{
+			testName:    "Malformed cursor.",
+			opts:        folder.PageOptions{Size: 1, Cursor: "not a cursor"},
+			expectError: folder.ErrInvalidCursor,
+		},
+
+		{
+			testName:    "Cursor from another sort order.",
+			opts:        folder.PageOptions{Size: 1, Cursor: page.NextCursor, Sort: folder.SortByName},
+			expectError: folder.ErrInvalidCursor,
+		},
+
+		{
+			testName:    "Folder doesnt exist.",
+			name:        "x",
+			opts:        folder.PageOptions{Size: 1},
+			expectError: folder.ErrFolderDoesNotExist,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			var err error
+			if tc.name == "" {
+				_, err = f.GetFoldersByOrgIDPage(orgId1, tc.opts)
+			} else {
+				_, err = f.GetAllChildFoldersPage(orgId1, tc.name, tc.opts)
+			}
+
+			assert.ErrorIs(t, err, tc.expectError, "expected error")
+		})
+	}
+}
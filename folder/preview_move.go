@@ -0,0 +1,86 @@
+package folder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MovePreview describes what a move would do, without doing it.
+type MovePreview struct {
+	// the moved folder and its descendants in its org whose paths would
+	// change, with the paths they would get
+	Changes []PathChange
+	// number of descendants in the folder's org whose paths would change
+	AffectedDescendants int
+	// number of path segments in the deepest folder of the moved subtree, roots are at depth 1
+	NewMaxDepth int
+	// policies the move would break. unlike the errors PreviewMove returns,
+	// these don't stop the move from being made.
+	Violations []error
+}
+
+// PreviewMove reports what ApplyMoves would do with move on its own. it fails
+// with the same errors as MoveFolder, or MoveFolderToRoot for an empty Dst.
+func (d *driver) PreviewMove(move Move) (MovePreview, error) {
+	srcNode, dstNode, err := d.prepareBatchMove(move)
+	if err != nil {
+		return MovePreview{}, err
+	}
+
+	preview := MovePreview{Changes: []PathChange{}, Violations: []error{}}
+
+	srcPaths, newPrefix := srcNode.Folder.Paths, movedPath(srcNode, dstNode)
+	position := plannedPositions(srcNode, dstNode, appendChild)[srcNode]
+
+	orgID := srcNode.Folder.OrgId
+
+	// other orgs' folders move along too, but like MoveFolderInOrg the preview
+	// only covers the moved folder's org.
+	walkSubtree(srcNode, func(n *FolderNode) {
+		if n.Folder.OrgId != orgID {
+			return
+		}
+
+		folder := *n.Folder
+		folder.Paths = replacePathPrefix(folder.Paths, srcPaths, newPrefix)
+		preview.NewMaxDepth = max(preview.NewMaxDepth, strings.Count(folder.Paths, ".")+1)
+
+		// e.g. a move to the folder's current parent.
+		if folder.Paths == n.Folder.Paths {
+			return
+		}
+
+		if n == srcNode {
+			folder.Position = position
+		} else {
+			preview.AffectedDescendants++
+		}
+
+		preview.Changes = append(preview.Changes, PathChange{Folder: folder, OldPaths: n.Folder.Paths})
+	})
+
+	if preview.NewMaxDepth > MaxDepth {
+		preview.Violations = append(preview.Violations, fmt.Errorf(
+			"%w: folders would be %d levels deep, the limit is %d",
+			ErrMaxDepthExceeded, preview.NewMaxDepth, MaxDepth,
+		))
+	}
+
+	if dstNode != nil && srcNode.Parent != dstNode {
+		children := 1
+		for _, child := range dstNode.Children {
+			if child.Folder.OrgId == orgID {
+				children++
+			}
+		}
+
+		if children > MaxChild {
+			preview.Violations = append(preview.Violations, fmt.Errorf(
+				"%w: %s would have %d children, the limit is %d",
+				ErrMaxChildExceeded, dstNode.Folder.Name, children, MaxChild,
+			))
+		}
+	}
+
+	return preview, nil
+}
@@ -0,0 +1,218 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_folder_PreviewMove(t *testing.T) {
+	t.Parallel()
+
+	orgId := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: orgId, Paths: "alpha.bravo.charlie.delta"},
+		{Name: "echo", OrgId: orgId, Paths: "echo"},
+		{Name: "foxtrot", OrgId: orgId, Paths: "echo.foxtrot"},
+		{Name: "golf", OrgId: orgId, Paths: "echo.foxtrot.golf"},
+		{Name: "hotel", OrgId: orgId, Paths: "hotel"},
+		{Name: "india", OrgId: orgId, Paths: "hotel.india"},
+		{Name: "juliet", OrgId: orgId, Paths: "hotel.juliet"},
+		{Name: "kilo", OrgId: orgId, Paths: "hotel.kilo"},
+		{Name: "lima", OrgId: orgId, Paths: "hotel.lima"},
+	}
+
+	tests := [...]struct {
+		testName          string
+		move              folder.Move
+		expectChanges     [][2]string
+		expectDescendants int
+		expectDepth       int
+		expectViolations  []error
+		expectError       error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName: "Move a subtree.",
+			move:     folder.Move{Name: "foxtrot", Dst: "alpha"},
+			expectChanges: [][2]string{
+				{"echo.foxtrot", "alpha.foxtrot"},
+				{"echo.foxtrot.golf", "alpha.foxtrot.golf"},
+			},
+			expectDescendants: 1,
+			expectDepth:       3,
+			expectViolations:  []error{},
+		},
+
+		{
+			testName: "Move a leaf.",
+			move:     folder.Move{Name: "delta", Dst: "echo"},
+			expectChanges: [][2]string{
+				{"alpha.bravo.charlie.delta", "echo.delta"},
+			},
+			expectDescendants: 0,
+			expectDepth:       2,
+			expectViolations:  []error{},
+		},
+
+		{
+			testName: "Move to root.",
+			move:     folder.Move{Name: "charlie", Dst: ""},
+			expectChanges: [][2]string{
+				{"alpha.bravo.charlie", "charlie"},
+				{"alpha.bravo.charlie.delta", "charlie.delta"},
+			},
+			expectDescendants: 1,
+			expectDepth:       2,
+			expectViolations:  []error{},
+		},
+
+		{
+			testName: "Move too deep.",
+			move:     folder.Move{Name: "echo", Dst: "delta"},
+			expectChanges: [][2]string{
+				{"echo", "alpha.bravo.charlie.delta.echo"},
+				{"echo.foxtrot", "alpha.bravo.charlie.delta.echo.foxtrot"},
+				{"echo.foxtrot.golf", "alpha.bravo.charlie.delta.echo.foxtrot.golf"},
+			},
+			expectDescendants: 2,
+			expectDepth:       7,
+			expectViolations:  []error{folder.ErrMaxDepthExceeded},
+		},
+
+		{
+			testName:          "Move to the current parent.",
+			move:              folder.Move{Name: "bravo", Dst: "alpha"},
+			expectChanges:     [][2]string{},
+			expectDescendants: 0,
+			expectDepth:       4,
+			expectViolations:  []error{},
+		},
+
+		{
+			testName: "Move under a full folder.",
+			move:     folder.Move{Name: "golf", Dst: "hotel"},
+			expectChanges: [][2]string{
+				{"echo.foxtrot.golf", "hotel.golf"},
+			},
+			expectDescendants: 0,
+			expectDepth:       2,
+			expectViolations:  []error{folder.ErrMaxChildExceeded},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Move to descendant.",
+			move:        folder.Move{Name: "alpha", Dst: "delta"},
+			expectError: folder.ErrMoveToDescendant,
+		},
+
+		{
+			testName:    "Source doesnt exist.",
+			move:        folder.Move{Name: "x", Dst: "alpha"},
+			expectError: folder.ErrSourceDoesNotExist,
+		},
+
+		{
+			testName:    "Root to root.",
+			move:        folder.Move{Name: "echo", Dst: ""},
+			expectError: folder.ErrAlreadyRoot,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders, folder.WithStatefulMoves())
+			assert.NoError(t, err, "unexpected error")
+
+			preview, err := f.PreviewMove(tc.move)
+
+			// previews never change the driver.
+			after, _ := f.GetFoldersByOrgID(orgId)
+			assert.Equal(t, folders, after, "driver changed")
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+				return
+			}
+
+			assert.NoError(t, err, "unexpected error")
+
+			changes := [][2]string{}
+			for _, change := range preview.Changes {
+				changes = append(changes, [2]string{change.OldPaths, change.Folder.Paths})
+			}
+			assert.Equal(t, tc.expectChanges, changes, "unexpected changes")
+			assert.Equal(t, tc.expectDescendants, preview.AffectedDescendants, "unexpected descendant count")
+			assert.Equal(t, tc.expectDepth, preview.NewMaxDepth, "unexpected depth")
+
+			assert.Len(t, preview.Violations, len(tc.expectViolations), "unexpected violations")
+			for i, violation := range tc.expectViolations {
+				assert.ErrorIs(t, preview.Violations[i], violation, "unexpected violation")
+			}
+
+			// the preview matches what the move then does.
+			var moved []folder.Folder
+			if tc.move.Dst == "" {
+				moved, err = f.MoveFolderToRoot(tc.move.Name)
+			} else {
+				moved, err = f.MoveFolder(tc.move.Name, tc.move.Dst)
+			}
+			assert.NoError(t, err, "unexpected error")
+
+			for _, change := range preview.Changes {
+				assert.Contains(t, moved, change.Folder, "preview differs from move")
+			}
+		})
+	}
+}
+
+func Test_folder_PreviewMove_CrossOrg(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	f, err := folder.NewDriver([]folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId2, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId2, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: orgId1, Paths: "alpha.bravo.delta"},
+		{Name: "echo", OrgId: orgId1, Paths: "echo"},
+		{Name: "foxtrot", OrgId: orgId2, Paths: "echo.foxtrot"},
+		{Name: "golf", OrgId: orgId2, Paths: "echo.golf"},
+		{Name: "hotel", OrgId: orgId2, Paths: "echo.hotel"},
+		{Name: "india", OrgId: orgId2, Paths: "echo.india"},
+	})
+	assert.NoError(t, err, "unexpected error")
+
+	preview, err := f.PreviewMove(folder.Move{Name: "alpha", Dst: "echo"})
+	assert.NoError(t, err, "unexpected error")
+
+	changes := [][2]string{}
+	for _, change := range preview.Changes {
+		changes = append(changes, [2]string{change.OldPaths, change.Folder.Paths})
+	}
+	assert.Equal(t, [][2]string{
+		{"alpha", "echo.alpha"},
+		{"alpha.bravo.delta", "echo.alpha.bravo.delta"},
+	}, changes, "other org's folders should be left out")
+	assert.Equal(t, 1, preview.AffectedDescendants, "unexpected descendant count")
+	assert.Equal(t, 4, preview.NewMaxDepth, "unexpected depth")
+
+	// echo's children are all in another org.
+	assert.Equal(t, []error{}, preview.Violations, "unexpected violations")
+}
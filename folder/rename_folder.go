@@ -0,0 +1,57 @@
+package folder
+
+import (
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+func (d *driver) RenameFolder(
+	orgID uuid.UUID,
+	oldName string,
+	newName string,
+) ([]PathChange, error) {
+	if err := validateFolderName(newName); err != nil {
+		return nil, err
+	}
+
+	node, err := d.lookupInOrg(orgID, oldName)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldName == newName {
+		return []PathChange{}, nil
+	}
+
+	if d.folderAt(orgID, childPath(parentPath(node.Folder.Paths), newName)) != nil {
+		return nil, ErrFolderAlreadyExists
+	}
+
+	d.unindexNode(node)
+	node.Folder.Name = newName
+	d.indexNode(node)
+
+	return renamePathSegment(node, newName, orgID), nil
+}
+
+// swaps the segment naming node for newName in the paths of node and its descendants.
+// every path is rewritten, but only changes to folders of orgID are returned.
+func renamePathSegment(node *FolderNode, newName string, orgID uuid.UUID) []PathChange {
+	res := []PathChange{}
+	segmentIdx := strings.Count(node.Folder.Paths, ".")
+
+	walkSubtree(node, func(n *FolderNode) {
+		oldPaths := n.Folder.Paths
+
+		segments := strings.Split(oldPaths, ".")
+		segments[segmentIdx] = newName
+		n.Folder.Paths = strings.Join(segments, ".")
+
+		if n.Folder.OrgId == orgID {
+			res = append(res, PathChange{Folder: *n.Folder, OldPaths: oldPaths})
+		}
+	})
+
+	return res
+}
@@ -0,0 +1,225 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_folder_RenameFolder(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: orgId1, Paths: "alpha.delta"},
+		{Name: "foxtrot", OrgId: orgId2, Paths: "foxtrot"},
+	}
+
+	tests := [...]struct {
+		testName    string
+		orgID       uuid.UUID
+		oldName     string
+		newName     string
+		expect      []folder.PathChange
+		expectAfter []folder.Folder
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName: "Rename a leaf.",
+			orgID:    orgId1,
+			oldName:  "delta",
+			newName:  "dingo",
+			expect: []folder.PathChange{
+				{Folder: folder.Folder{Name: "dingo", OrgId: orgId1, Paths: "alpha.dingo"}, OldPaths: "alpha.delta"},
+			},
+			expectAfter: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+				{Name: "dingo", OrgId: orgId1, Paths: "alpha.dingo"},
+			},
+		},
+
+		{
+			testName: "Rename a root rewrites every descendant.",
+			orgID:    orgId1,
+			oldName:  "alpha",
+			newName:  "apex",
+			expect: []folder.PathChange{
+				{Folder: folder.Folder{Name: "apex", OrgId: orgId1, Paths: "apex"}, OldPaths: "alpha"},
+				{Folder: folder.Folder{Name: "bravo", OrgId: orgId1, Paths: "apex.bravo"}, OldPaths: "alpha.bravo"},
+				{Folder: folder.Folder{Name: "charlie", OrgId: orgId1, Paths: "apex.bravo.charlie"}, OldPaths: "alpha.bravo.charlie"},
+				{Folder: folder.Folder{Name: "delta", OrgId: orgId1, Paths: "apex.delta"}, OldPaths: "alpha.delta"},
+			},
+			expectAfter: []folder.Folder{
+				{Name: "apex", OrgId: orgId1, Paths: "apex"},
+				{Name: "bravo", OrgId: orgId1, Paths: "apex.bravo"},
+				{Name: "charlie", OrgId: orgId1, Paths: "apex.bravo.charlie"},
+				{Name: "delta", OrgId: orgId1, Paths: "apex.delta"},
+			},
+		},
+
+		{
+			testName: "Only the renamed segment changes.",
+			orgID:    orgId1,
+			oldName:  "bravo",
+			newName:  "alpha_2",
+			expect: []folder.PathChange{
+				{Folder: folder.Folder{Name: "alpha_2", OrgId: orgId1, Paths: "alpha.alpha_2"}, OldPaths: "alpha.bravo"},
+				{Folder: folder.Folder{Name: "charlie", OrgId: orgId1, Paths: "alpha.alpha_2.charlie"}, OldPaths: "alpha.bravo.charlie"},
+			},
+			expectAfter: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "alpha_2", OrgId: orgId1, Paths: "alpha.alpha_2"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.alpha_2.charlie"},
+				{Name: "delta", OrgId: orgId1, Paths: "alpha.delta"},
+			},
+		},
+
+		{
+			testName: "Renaming to the same name is a no-op.",
+			orgID:    orgId1,
+			oldName:  "delta",
+			newName:  "delta",
+			expect:   []folder.PathChange{},
+			expectAfter: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+				{Name: "delta", OrgId: orgId1, Paths: "alpha.delta"},
+			},
+		},
+
+		{
+			testName: "Reuses a name from another branch.",
+			orgID:    orgId1,
+			oldName:  "delta",
+			newName:  "charlie",
+			expect: []folder.PathChange{
+				{Folder: folder.Folder{Name: "charlie", OrgId: orgId1, Paths: "alpha.charlie"}, OldPaths: "alpha.delta"},
+			},
+			expectAfter: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.charlie"},
+			},
+		},
+
+		{
+			testName: "Reuses a name from another org.",
+			orgID:    orgId1,
+			oldName:  "delta",
+			newName:  "foxtrot",
+			expect: []folder.PathChange{
+				{Folder: folder.Folder{Name: "foxtrot", OrgId: orgId1, Paths: "alpha.foxtrot"}, OldPaths: "alpha.delta"},
+			},
+			expectAfter: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+				{Name: "foxtrot", OrgId: orgId1, Paths: "alpha.foxtrot"},
+			},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Collides with a sibling.",
+			orgID:       orgId1,
+			oldName:     "delta",
+			newName:     "bravo",
+			expectError: folder.ErrFolderAlreadyExists,
+		},
+
+		{
+			testName:    "Invalid new name.",
+			orgID:       orgId1,
+			oldName:     "delta",
+			newName:     "del.ta",
+			expectError: folder.ErrInvalidFolderName,
+		},
+
+		{
+			testName:    "Folder doesnt exist.",
+			orgID:       orgId1,
+			oldName:     "x",
+			newName:     "y",
+			expectError: folder.ErrFolderDoesNotExist,
+		},
+
+		{
+			testName:    "Folder in a different org.",
+			orgID:       orgId1,
+			oldName:     "foxtrot",
+			newName:     "fox",
+			expectError: folder.ErrFolderDoesNotExistInOrg,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			result, err := f.RenameFolder(tc.orgID, tc.oldName, tc.newName)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+				return
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+
+			assert.Equal(t, tc.expect, result, "unexpected result")
+
+			after, err := f.GetFoldersByOrgID(tc.orgID)
+			assert.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectAfter, after, "unexpected folders after rename")
+		})
+	}
+}
+
+func Test_folder_RenameFolder_CrossOrg(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "golf", OrgId: orgId1, Paths: "golf"},
+		{Name: "hotel", OrgId: orgId2, Paths: "golf.hotel"},
+		{Name: "india", OrgId: orgId1, Paths: "golf.hotel.india"},
+	}
+
+	f, err := folder.NewDriver(folders)
+	assert.NoError(t, err, "unexpected error")
+
+	result, err := f.RenameFolder(orgId1, "golf", "gamma")
+	assert.NoError(t, err, "unexpected error")
+
+	assert.Equal(t, []folder.PathChange{
+		{Folder: folder.Folder{Name: "gamma", OrgId: orgId1, Paths: "gamma"}, OldPaths: "golf"},
+		{Folder: folder.Folder{Name: "india", OrgId: orgId1, Paths: "gamma.hotel.india"}, OldPaths: "golf.hotel.india"},
+	}, result, "unexpected result")
+
+	after, err := f.GetFoldersByOrgID(orgId2)
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, []folder.Folder{
+		{Name: "hotel", OrgId: orgId2, Paths: "gamma.hotel"},
+	}, after, "other org's paths not rewritten")
+}
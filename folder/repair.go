@@ -0,0 +1,294 @@
+package folder
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// OrphanPolicy decides what Repair does with folders whose parent doesn't exist
+// or belongs to another org.
+type OrphanPolicy int
+
+const (
+	// OrphanKeep leaves orphans as they are.
+	OrphanKeep OrphanPolicy = iota
+	// OrphanReRoot turns each orphan into a root, moving its descendants with it.
+	OrphanReRoot
+	// OrphanDrop removes orphans along with their descendants.
+	OrphanDrop
+)
+
+// RepairPolicy picks which fixes Repair applies. The zero value changes nothing.
+type RepairPolicy struct {
+	Orphans OrphanPolicy
+	// rebuild every path from the folder's parent in the tree and its name,
+	// which also fixes names that don't match their path.
+	RegeneratePaths bool
+	// rename folders that clash with a sibling, and give folders with a
+	// repeated ID a new one. Without it such datasets can't be repaired.
+	RenameDuplicates bool
+	// remove folders with an empty or illegal name or path.
+	DropInvalid bool
+}
+
+// RepairAction is the kind of fix applied to a folder.
+type RepairAction int
+
+const (
+	RepairDropped RepairAction = iota
+	RepairRenamed
+	RepairNewID
+	RepairReRooted
+	// the folder's path changed because it or an ancestor was fixed.
+	RepairPathRewritten
+)
+
+func (a RepairAction) String() string {
+	switch a {
+	case RepairDropped:
+		return "dropped"
+	case RepairRenamed:
+		return "renamed"
+	case RepairNewID:
+		return "new ID"
+	case RepairReRooted:
+		return "re-rooted"
+	case RepairPathRewritten:
+		return "path rewritten"
+	}
+
+	return fmt.Sprintf("RepairAction(%d)", int(a))
+}
+
+// RepairChange describes one fix. After is the zero Folder for dropped folders.
+type RepairChange struct {
+	Action RepairAction
+	Before Folder
+	After  Folder
+}
+
+// Repair fixes the issues Validate reports according to policy, returning the
+// repaired folders in their original order and every change made, in the order
+// the fixes were applied. The input is left untouched, so the changes can be
+// reviewed before the result is written back with WriteSampleData.
+func Repair(folders []Folder, policy RepairPolicy) ([]Folder, []RepairChange, error) {
+	changes := []RepairChange{}
+
+	working := []Folder{}
+	for _, f := range folders {
+		if policy.DropInvalid && !isValidRow(f) {
+			changes = append(changes, RepairChange{Action: RepairDropped, Before: f})
+			continue
+		}
+
+		working = append(working, f)
+	}
+
+	if policy.RenameDuplicates {
+		changes = append(changes, renameDuplicates(working)...)
+	}
+
+	before := slices.Clone(working)
+
+	nodes, err := BuildFolderTree(working)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dropped := map[*FolderNode]bool{}
+	reRooted := map[*FolderNode]bool{}
+
+	for _, node := range nodes {
+		if !isOrphan(node) || dropped[node] {
+			continue
+		}
+
+		switch policy.Orphans {
+		case OrphanReRoot:
+			detachNode(node)
+			rebasePaths(node, node.Folder.Name)
+			reRooted[node] = true
+
+		case OrphanDrop:
+			detachNode(node)
+			walkSubtree(node, func(n *FolderNode) {
+				dropped[n] = true
+			})
+		}
+	}
+
+	if policy.RegeneratePaths {
+		for _, node := range nodes {
+			if node.Parent == nil && !dropped[node] {
+				regeneratePaths(node, parentPath(node.Folder.Paths))
+			}
+		}
+	}
+
+	// re-rooting and regenerating can move a folder onto a path that's
+	// already in use, so clashes are looked for again on the final paths.
+	renames, err := renameClashes(nodes, before, dropped, policy.RenameDuplicates)
+	if err != nil {
+		return nil, nil, err
+	}
+	changes = append(changes, renames...)
+
+	res := []Folder{}
+	for i, node := range nodes {
+		switch {
+		case dropped[node]:
+			changes = append(changes, RepairChange{Action: RepairDropped, Before: before[i]})
+			continue
+
+		case reRooted[node]:
+			changes = append(changes, RepairChange{Action: RepairReRooted, Before: before[i], After: *node.Folder})
+
+		case node.Folder.Paths != before[i].Paths:
+			changes = append(changes, RepairChange{Action: RepairPathRewritten, Before: before[i], After: *node.Folder})
+		}
+
+		res = append(res, *node.Folder)
+	}
+
+	return res, changes, nil
+}
+
+// reports whether node's parent is missing, or is a folder in another org.
+func isOrphan(node *FolderNode) bool {
+	if node.Parent == nil {
+		return parentPath(node.Folder.Paths) != ""
+	}
+
+	return node.Parent.Folder.OrgId != node.Folder.OrgId
+}
+
+func isValidRow(f Folder) bool {
+	if validateFolderName(f.Name) != nil || f.Paths == "" {
+		return false
+	}
+
+	for _, segment := range strings.Split(f.Paths, ".") {
+		if validateFolderName(segment) != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// gives later folders clashing on path or ID a fresh name or ID, in place.
+func renameDuplicates(folders []Folder) []RepairChange {
+	type pathKey struct {
+		orgID uuid.UUID
+		path  string
+	}
+
+	changes := []RepairChange{}
+
+	paths := map[pathKey]bool{}
+	for _, f := range folders {
+		paths[pathKey{orgID: f.OrgId, path: f.Paths}] = true
+	}
+
+	seenPaths := map[pathKey]bool{}
+	seenIDs := map[uuid.UUID]bool{}
+
+	for i := range folders {
+		f := &folders[i]
+
+		if f.ID != uuid.Nil && seenIDs[f.ID] {
+			before := *f
+			f.ID = uuid.Must(uuid.NewV4())
+			changes = append(changes, RepairChange{Action: RepairNewID, Before: before, After: *f})
+		}
+		seenIDs[f.ID] = true
+
+		key := pathKey{orgID: f.OrgId, path: f.Paths}
+		if seenPaths[key] {
+			before := *f
+			parent := parentPath(f.Paths)
+
+			for n := 2; paths[key]; n++ {
+				f.Name = fmt.Sprintf("%s-%d", before.Name, n)
+				f.Paths = childPath(parent, f.Name)
+				key.path = f.Paths
+			}
+
+			paths[key] = true
+			changes = append(changes, RepairChange{Action: RepairRenamed, Before: before, After: *f})
+		}
+		seenPaths[key] = true
+	}
+
+	return changes
+}
+
+// renames folders whose path was changed onto one already in use, walking
+// parents before children. folders that kept their path keep their name.
+// fails with ErrDuplicateFolderPath if there is a clash and rename is false.
+func renameClashes(
+	nodes []*FolderNode,
+	before []Folder,
+	dropped map[*FolderNode]bool,
+	rename bool,
+) ([]RepairChange, error) {
+	type pathKey struct {
+		orgID uuid.UUID
+		path  string
+	}
+
+	changes := []RepairChange{}
+
+	taken := map[pathKey]*FolderNode{}
+	for i, node := range nodes {
+		if !dropped[node] && node.Folder.Paths == before[i].Paths {
+			taken[pathKey{orgID: node.Folder.OrgId, path: node.Folder.Paths}] = node
+		}
+	}
+
+	var err error
+	for _, root := range nodes {
+		if root.Parent != nil || dropped[root] {
+			continue
+		}
+
+		walkSubtree(root, func(n *FolderNode) {
+			key := pathKey{orgID: n.Folder.OrgId, path: n.Folder.Paths}
+			if owner, ok := taken[key]; ok && owner != n {
+				if !rename {
+					err = ErrDuplicateFolderPath
+					return
+				}
+
+				prior := *n.Folder
+				parent := parentPath(prior.Paths)
+
+				name := prior.Name
+				for k := 2; taken[key] != nil; k++ {
+					name = fmt.Sprintf("%s-%d", prior.Name, k)
+					key.path = childPath(parent, name)
+				}
+
+				n.Folder.Name = name
+				rebasePaths(n, key.path)
+				changes = append(changes, RepairChange{Action: RepairRenamed, Before: prior, After: *n.Folder})
+			}
+
+			taken[key] = n
+		})
+	}
+
+	return changes, err
+}
+
+// sets the path of node and its descendants from their names, with node under parentPath.
+func regeneratePaths(node *FolderNode, parentPath string) {
+	node.Folder.Paths = childPath(parentPath, node.Folder.Name)
+
+	for _, child := range node.Children {
+		regeneratePaths(child, node.Folder.Paths)
+	}
+}
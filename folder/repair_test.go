@@ -0,0 +1,311 @@
+package folder_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_folder_Repair(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	tests := [...]struct {
+		testName      string
+		folders       []folder.Folder
+		policy        folder.RepairPolicy
+		expect        []folder.Folder
+		expectChanges []folder.RepairChange
+		expectError   error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName: "Zero policy changes nothing.",
+			folders: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+			},
+			policy: folder.RepairPolicy{},
+			expect: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+			},
+			expectChanges: []folder.RepairChange{},
+		},
+
+		{
+			testName: "Re-root orphans along with their descendants.",
+			folders: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+				{Name: "delta", OrgId: orgId1, Paths: "alpha.bravo.charlie.delta"},
+			},
+			policy: folder.RepairPolicy{Orphans: folder.OrphanReRoot},
+			expect: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "charlie", OrgId: orgId1, Paths: "charlie"},
+				{Name: "delta", OrgId: orgId1, Paths: "charlie.delta"},
+			},
+			expectChanges: []folder.RepairChange{
+				{
+					Action: folder.RepairReRooted,
+					Before: folder.Folder{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+					After:  folder.Folder{Name: "charlie", OrgId: orgId1, Paths: "charlie"},
+				},
+				{
+					Action: folder.RepairPathRewritten,
+					Before: folder.Folder{Name: "delta", OrgId: orgId1, Paths: "alpha.bravo.charlie.delta"},
+					After:  folder.Folder{Name: "delta", OrgId: orgId1, Paths: "charlie.delta"},
+				},
+			},
+		},
+
+		{
+			testName: "Drop orphans along with their descendants.",
+			folders: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+				{Name: "delta", OrgId: orgId1, Paths: "alpha.bravo.charlie.delta"},
+			},
+			policy: folder.RepairPolicy{Orphans: folder.OrphanDrop},
+			expect: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+			},
+			expectChanges: []folder.RepairChange{
+				{
+					Action: folder.RepairDropped,
+					Before: folder.Folder{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+				},
+				{
+					Action: folder.RepairDropped,
+					Before: folder.Folder{Name: "delta", OrgId: orgId1, Paths: "alpha.bravo.charlie.delta"},
+				},
+			},
+		},
+
+		{
+			testName: "Regenerate paths from parent and name.",
+			folders: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "alpha.b"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.b.charlie"},
+			},
+			policy: folder.RepairPolicy{RegeneratePaths: true},
+			expect: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+			},
+			expectChanges: []folder.RepairChange{
+				{
+					Action: folder.RepairPathRewritten,
+					Before: folder.Folder{Name: "bravo", OrgId: orgId1, Paths: "alpha.b"},
+					After:  folder.Folder{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+				},
+				{
+					Action: folder.RepairPathRewritten,
+					Before: folder.Folder{Name: "charlie", OrgId: orgId1, Paths: "alpha.b.charlie"},
+					After:  folder.Folder{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+				},
+			},
+		},
+
+		{
+			testName: "Rename duplicates.",
+			folders: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "alpha-2", OrgId: orgId1, Paths: "alpha-2"},
+			},
+			policy: folder.RepairPolicy{RenameDuplicates: true},
+			expect: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "alpha-3", OrgId: orgId1, Paths: "alpha-3"},
+				{Name: "alpha-2", OrgId: orgId1, Paths: "alpha-2"},
+			},
+			expectChanges: []folder.RepairChange{
+				{
+					Action: folder.RepairRenamed,
+					Before: folder.Folder{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+					After:  folder.Folder{Name: "alpha-3", OrgId: orgId1, Paths: "alpha-3"},
+				},
+			},
+		},
+
+		{
+			testName: "Drop invalid rows, re-rooting what was below them.",
+			folders: []folder.Folder{
+				{Name: "al pha", OrgId: orgId1, Paths: "al pha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "al pha.bravo"},
+				{Name: "", OrgId: orgId1, Paths: "charlie"},
+				{Name: "delta", OrgId: orgId1, Paths: "delta"},
+			},
+			policy: folder.RepairPolicy{DropInvalid: true, Orphans: folder.OrphanReRoot},
+			expect: []folder.Folder{
+				{Name: "delta", OrgId: orgId1, Paths: "delta"},
+			},
+			expectChanges: []folder.RepairChange{
+				{
+					Action: folder.RepairDropped,
+					Before: folder.Folder{Name: "al pha", OrgId: orgId1, Paths: "al pha"},
+				},
+				{
+					Action: folder.RepairDropped,
+					Before: folder.Folder{Name: "bravo", OrgId: orgId1, Paths: "al pha.bravo"},
+				},
+				{
+					Action: folder.RepairDropped,
+					Before: folder.Folder{Name: "", OrgId: orgId1, Paths: "charlie"},
+				},
+			},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName: "Duplicates without renaming.",
+			folders: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+			},
+			policy:      folder.RepairPolicy{Orphans: folder.OrphanDrop},
+			expectError: folder.ErrDuplicateFolderPath,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			result, changes, err := folder.Repair(tc.folders, tc.policy)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+				return
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+
+			assert.Equal(t, tc.expect, result, "unexpected result")
+			assert.Equal(t, tc.expectChanges, changes, "unexpected changes")
+		})
+	}
+}
+
+func Test_folder_Repair_PassesValidation(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+	id := uuid.Must(uuid.NewV4())
+
+	allFixes := folder.RepairPolicy{
+		Orphans:          folder.OrphanReRoot,
+		RegeneratePaths:  true,
+		RenameDuplicates: true,
+		DropInvalid:      true,
+	}
+
+	tests := [...]struct {
+		testName string
+		folders  []folder.Folder
+		policy   folder.RepairPolicy
+	}{
+		{
+			testName: "Every kind of issue.",
+			folders: []folder.Folder{
+				{ID: id, Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{ID: id, Name: "bravo", OrgId: orgId1, Paths: "alpha.b"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.b.charlie"},
+				{Name: "delta", OrgId: orgId1, Paths: "x.delta"},
+				{Name: "echo", OrgId: orgId1, Paths: "alpha.b.ec ho"},
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "x", OrgId: orgId2, Paths: "x"},
+				{Name: "q", OrgId: orgId1, Paths: "x.q"},
+			},
+			policy: allFixes,
+		},
+
+		{
+			testName: "Dropped folder under another org.",
+			folders: []folder.Folder{
+				{Name: "x", OrgId: orgId2, Paths: "x"},
+				{Name: "q", OrgId: orgId1, Paths: "x.q"},
+				{Name: "r", OrgId: orgId1, Paths: "x.q.r"},
+			},
+			policy: folder.RepairPolicy{Orphans: folder.OrphanDrop},
+		},
+
+		{
+			testName: "Re-rooted orphan clashes with a root.",
+			folders: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "alpha", OrgId: orgId1, Paths: "x.alpha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "x.alpha.bravo"},
+			},
+			policy: folder.RepairPolicy{Orphans: folder.OrphanReRoot, RenameDuplicates: true},
+		},
+
+		{
+			testName: "Re-rooted orphan clashes with a later root.",
+			folders: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "x.alpha"},
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+			},
+			policy: folder.RepairPolicy{Orphans: folder.OrphanReRoot, RenameDuplicates: true},
+		},
+
+		{
+			testName: "Regenerated path clashes with a sibling.",
+			folders: []folder.Folder{
+				{Name: "a", OrgId: orgId1, Paths: "a"},
+				{Name: "b", OrgId: orgId1, Paths: "a.c"},
+				{Name: "b", OrgId: orgId1, Paths: "a.b"},
+			},
+			policy: allFixes,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			input := slices.Clone(tc.folders)
+			assert.False(t, folder.Validate(tc.folders).OK(), "dataset should start out broken")
+
+			result, _, err := folder.Repair(tc.folders, tc.policy)
+			assert.NoError(t, err, "unexpected error")
+			assert.Equal(t, []folder.Issue{}, folder.Validate(result).Issues, "repaired dataset still has issues")
+
+			_, err = folder.NewDriver(result, folder.WithValidation())
+			assert.NoError(t, err, "repaired dataset doesnt load")
+
+			// the input isn't modified.
+			assert.Equal(t, input, tc.folders, "input was mutated")
+		})
+	}
+}
+
+func Test_folder_Repair_ClashWithoutRenaming(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "alpha", OrgId: orgId1, Paths: "x.alpha"},
+	}
+
+	_, _, err := folder.Repair(folders, folder.RepairPolicy{Orphans: folder.OrphanReRoot})
+	assert.ErrorIs(t, err, folder.ErrDuplicateFolderPath, "expected error")
+}
@@ -0,0 +1,354 @@
+package folder
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid"
+)
+
+// SearchMode decides how SearchByName compares a query against folder names.
+type SearchMode int
+
+const (
+	// SearchPrefix matches names starting with the query.
+	SearchPrefix SearchMode = iota
+	// SearchSubstring matches names containing the query.
+	SearchSubstring
+	// SearchCaseInsensitive matches names containing the query, ignoring case.
+	SearchCaseInsensitive
+	// SearchFuzzy matches like SearchCaseInsensitive, and also names where the
+	// whole name or one of its '-' or '_' separated words is a few edits away
+	// from the query, allowing one edit for every three runes of the query.
+	SearchFuzzy
+)
+
+// relevance of a match, lower is better. fuzzy matches rank after these by
+// their edit distance.
+const (
+	relevanceExact = iota
+	relevancePrefix
+	relevanceSubstring
+	relevanceFuzzy
+)
+
+// per-org index over the driver's folder names, kept up to date by
+// indexNode and unindexNode so searches never have to build it.
+type nameIndex map[uuid.UUID]*orgNameIndex
+
+type orgNameIndex struct {
+	// number of folders with each name
+	counts map[string]int
+	// distinct names, sorted, for prefix searches
+	sorted []string
+	// every lowercased 1 to 3 rune substring of a name, to the names that
+	// contain it, for substring searches
+	grams map[string]map[string]bool
+	// lowercased names and their '-' or '_' separated words, to the names
+	// they come from, for fuzzy searches
+	terms map[string]map[string]bool
+	// the terms of each length in runes
+	termsByLen map[int]map[string]bool
+}
+
+// longest gram kept in orgNameIndex.grams
+const maxGram = 3
+
+func (index nameIndex) add(orgID uuid.UUID, name string) {
+	org, ok := index[orgID]
+	if !ok {
+		org = &orgNameIndex{
+			counts:     map[string]int{},
+			grams:      map[string]map[string]bool{},
+			terms:      map[string]map[string]bool{},
+			termsByLen: map[int]map[string]bool{},
+		}
+		index[orgID] = org
+	}
+
+	org.counts[name]++
+	if org.counts[name] > 1 {
+		return
+	}
+
+	i, _ := slices.BinarySearch(org.sorted, name)
+	org.sorted = slices.Insert(org.sorted, i, name)
+
+	for _, gram := range nameGrams(name) {
+		addPosting(org.grams, gram, name)
+	}
+
+	for _, term := range nameTerms(name) {
+		if addPosting(org.terms, term, name) {
+			addPosting(org.termsByLen, utf8.RuneCountInString(term), term)
+		}
+	}
+}
+
+func (index nameIndex) remove(orgID uuid.UUID, name string) {
+	org := index[orgID]
+
+	org.counts[name]--
+	if org.counts[name] > 0 {
+		return
+	}
+	delete(org.counts, name)
+
+	if len(org.counts) == 0 {
+		delete(index, orgID)
+		return
+	}
+
+	i, _ := slices.BinarySearch(org.sorted, name)
+	org.sorted = slices.Delete(org.sorted, i, i+1)
+
+	for _, gram := range nameGrams(name) {
+		removePosting(org.grams, gram, name)
+	}
+
+	for _, term := range nameTerms(name) {
+		if removePosting(org.terms, term, name) {
+			removePosting(org.termsByLen, utf8.RuneCountInString(term), term)
+		}
+	}
+}
+
+// adds value to the set at key, reporting whether the set is new.
+func addPosting[K comparable](postings map[K]map[string]bool, key K, value string) bool {
+	set, ok := postings[key]
+	if !ok {
+		set = map[string]bool{}
+		postings[key] = set
+	}
+	set[value] = true
+
+	return !ok
+}
+
+// removes value from the set at key, reporting whether the set is now gone.
+func removePosting[K comparable](postings map[K]map[string]bool, key K, value string) bool {
+	delete(postings[key], value)
+	if len(postings[key]) > 0 {
+		return false
+	}
+
+	delete(postings, key)
+	return true
+}
+
+// every distinct lowercased substring of name up to maxGram runes long.
+func nameGrams(name string) []string {
+	runes := []rune(strings.ToLower(name))
+
+	seen := map[string]bool{}
+	grams := []string{}
+	for i := range runes {
+		for n := 1; n <= maxGram && i+n <= len(runes); n++ {
+			gram := string(runes[i : i+n])
+			if !seen[gram] {
+				seen[gram] = true
+				grams = append(grams, gram)
+			}
+		}
+	}
+
+	return grams
+}
+
+// the lowercased name along with its '-' or '_' separated words.
+func nameTerms(name string) []string {
+	folded := strings.ToLower(name)
+	terms := []string{folded}
+
+	words := strings.FieldsFunc(folded, func(r rune) bool {
+		return r == '-' || r == '_'
+	})
+	for _, word := range words {
+		if !slices.Contains(terms, word) {
+			terms = append(terms, word)
+		}
+	}
+
+	return terms
+}
+
+func (d *driver) SearchByName(orgID uuid.UUID, query string, mode SearchMode) ([]Folder, error) {
+	if query == "" {
+		return nil, ErrEmptySearchQuery
+	}
+
+	if mode < SearchPrefix || mode > SearchFuzzy {
+		return nil, ErrUnknownSearchMode
+	}
+
+	type hit struct {
+		node      *FolderNode
+		relevance int
+		depth     int
+	}
+
+	hits := []hit{}
+	org, ok := d.searchIndex[orgID]
+	if !ok {
+		return []Folder{}, nil
+	}
+
+	for name, relevance := range org.matches(query, mode) {
+		for _, node := range d.nameToNodes[name] {
+			if node.Folder.OrgId != orgID {
+				continue
+			}
+
+			hits = append(hits, hit{
+				node:      node,
+				relevance: relevance,
+				depth:     strings.Count(node.Folder.Paths, "."),
+			})
+		}
+	}
+
+	slices.SortFunc(hits, func(a, b hit) int {
+		return cmp.Or(
+			cmp.Compare(a.relevance, b.relevance),
+			cmp.Compare(a.depth, b.depth),
+			strings.Compare(a.node.Folder.Paths, b.node.Folder.Paths),
+		)
+	})
+
+	res := []Folder{}
+	for _, h := range hits {
+		res = append(res, *h.node.Folder)
+	}
+
+	return res, nil
+}
+
+// returns every indexed name matching query, along with its relevance.
+func (org *orgNameIndex) matches(query string, mode SearchMode) map[string]int {
+	res := map[string]int{}
+
+	if mode == SearchPrefix {
+		start, _ := slices.BinarySearch(org.sorted, query)
+		for _, name := range org.sorted[start:] {
+			if !strings.HasPrefix(name, query) {
+				break
+			}
+
+			res[name] = textRelevance(name, query)
+		}
+
+		return res
+	}
+
+	folded := strings.ToLower(query)
+	for name := range org.containing(folded) {
+		switch {
+		case mode != SearchSubstring:
+			res[name] = textRelevance(strings.ToLower(name), folded)
+		case strings.Contains(name, query):
+			res[name] = textRelevance(name, query)
+		}
+	}
+
+	if mode == SearchFuzzy {
+		for name, dist := range org.near(folded) {
+			if _, ok := res[name]; !ok {
+				res[name] = relevanceFuzzy + dist
+			}
+		}
+	}
+
+	return res
+}
+
+// returns the names whose lowercased form contains folded. the grams narrow
+// the names down, then each candidate is checked.
+func (org *orgNameIndex) containing(folded string) map[string]bool {
+	runes := []rune(folded)
+	if len(runes) <= maxGram {
+		return org.grams[folded]
+	}
+
+	// start from the rarest gram to keep the candidates few.
+	candidates := org.grams[string(runes[:maxGram])]
+	for i := 1; i+maxGram <= len(runes); i++ {
+		if set := org.grams[string(runes[i:i+maxGram])]; len(set) < len(candidates) {
+			candidates = set
+		}
+	}
+
+	res := map[string]bool{}
+	for name := range candidates {
+		if strings.Contains(strings.ToLower(name), folded) {
+			res[name] = true
+		}
+	}
+
+	return res
+}
+
+// returns the names with a term within the allowed edit distance of folded,
+// along with the smallest such distance. only terms of a length that could
+// be close enough are compared.
+func (org *orgNameIndex) near(folded string) map[string]int {
+	length := utf8.RuneCountInString(folded)
+	limit := max(1, length/3)
+
+	res := map[string]int{}
+	for n := max(1, length-limit); n <= length+limit; n++ {
+		for term := range org.termsByLen[n] {
+			dist := editDistance(term, folded)
+			if dist > limit {
+				continue
+			}
+
+			for name := range org.terms[term] {
+				if best, ok := res[name]; !ok || dist < best {
+					res[name] = dist
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+// relevance of a name that is known to contain query.
+func textRelevance(name string, query string) int {
+	switch {
+	case name == query:
+		return relevanceExact
+	case strings.HasPrefix(name, query):
+		return relevancePrefix
+	default:
+		return relevanceSubstring
+	}
+}
+
+// levenshtein distance between a and b, counted in runes.
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
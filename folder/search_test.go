@@ -0,0 +1,204 @@
+package folder_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func searchFolders(orgId1 uuid.UUID, orgId2 uuid.UUID) []folder.Folder {
+	return []folder.Folder{
+		{Name: "lion", OrgId: orgId1, Paths: "lion"},
+		{Name: "bursting-lionheart", OrgId: orgId1, Paths: "lion.bursting-lionheart"},
+		{Name: "lionel", OrgId: orgId1, Paths: "lion.bursting-lionheart.lionel"},
+		{Name: "zoo", OrgId: orgId1, Paths: "zoo"},
+		{Name: "Lioness", OrgId: orgId1, Paths: "zoo.Lioness"},
+		{Name: "lyon", OrgId: orgId1, Paths: "zoo.lyon"},
+		{Name: "sea-lion", OrgId: orgId1, Paths: "zoo.sea-lion"},
+		{Name: "lion", OrgId: orgId2, Paths: "lion"},
+	}
+}
+
+func Test_folder_SearchByName(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	tests := [...]struct {
+		testName    string
+		query       string
+		mode        folder.SearchMode
+		expect      []string
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName: "Prefix.",
+			query:    "lion",
+			mode:     folder.SearchPrefix,
+			expect:   []string{"lion", "lionel"},
+		},
+
+		{
+			testName: "Substring.",
+			query:    "lion",
+			mode:     folder.SearchSubstring,
+			expect:   []string{"lion", "lionel", "bursting-lionheart", "sea-lion"},
+		},
+
+		{
+			testName: "Case insensitive.",
+			query:    "LION",
+			mode:     folder.SearchCaseInsensitive,
+			expect:   []string{"lion", "Lioness", "lionel", "bursting-lionheart", "sea-lion"},
+		},
+
+		{
+			testName: "Fuzzy ranks substrings before edits.",
+			query:    "lion",
+			mode:     folder.SearchFuzzy,
+			expect:   []string{"lion", "Lioness", "lionel", "bursting-lionheart", "sea-lion", "lyon"},
+		},
+
+		{
+			testName: "Fuzzy matches words within a name.",
+			query:    "lyon",
+			mode:     folder.SearchFuzzy,
+			expect:   []string{"lyon", "lion", "sea-lion"},
+		},
+
+		{
+			testName: "Substring longer than a gram.",
+			query:    "ionhe",
+			mode:     folder.SearchSubstring,
+			expect:   []string{"bursting-lionheart"},
+		},
+
+		{
+			testName: "Short substring.",
+			query:    "yo",
+			mode:     folder.SearchCaseInsensitive,
+			expect:   []string{"lyon"},
+		},
+
+		{
+			testName: "Case insensitive with an unknown gram.",
+			query:    "lionz",
+			mode:     folder.SearchCaseInsensitive,
+			expect:   []string{},
+		},
+
+		{
+			testName: "No matches.",
+			query:    "xyz",
+			mode:     folder.SearchFuzzy,
+			expect:   []string{},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Empty query.",
+			query:       "",
+			mode:        folder.SearchPrefix,
+			expectError: folder.ErrEmptySearchQuery,
+		},
+
+		{
+			testName:    "Unknown mode.",
+			query:       "lion",
+			mode:        folder.SearchMode(99),
+			expectError: folder.ErrUnknownSearchMode,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(searchFolders(orgId1, orgId2))
+			assert.NoError(t, err, "unexpected error")
+
+			result, err := f.SearchByName(orgId1, tc.query, tc.mode)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+				return
+			}
+
+			assert.NoError(t, err, "unexpected error")
+			names := []string{}
+			for _, f := range result {
+				names = append(names, f.Name)
+			}
+			assert.Equal(t, tc.expect, names, "unexpected results")
+		})
+	}
+}
+
+func Test_folder_SearchByName_AfterMutation(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	f, err := folder.NewDriver(searchFolders(orgId1, orgId2))
+	assert.NoError(t, err, "unexpected error")
+
+	_, err = f.RenameFolder(orgId1, "lyon", "lyonnais")
+	assert.NoError(t, err, "unexpected error")
+
+	_, err = f.CreateFolder(orgId1, "zoo", "lyonesse")
+	assert.NoError(t, err, "unexpected error")
+
+	result, err := f.SearchByName(orgId1, "lyon", folder.SearchPrefix)
+	assert.NoError(t, err, "unexpected error")
+
+	names := []string{}
+	for _, f := range result {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"lyonesse", "lyonnais"}, names, "unexpected results")
+
+	_, err = f.DeleteFolder(orgId1, "lyonnais", folder.DeleteRefuseIfNonEmpty)
+	assert.NoError(t, err, "unexpected error")
+
+	result, err = f.SearchByName(orgId1, "lyonnais", folder.SearchFuzzy)
+	assert.NoError(t, err, "unexpected error")
+	assert.Empty(t, result, "deleted folder still found")
+}
+
+func Test_folder_SearchByName_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	f, err := folder.NewDriver(searchFolders(orgId1, orgId2))
+	assert.NoError(t, err, "unexpected error")
+
+	_, err = f.CreateRootFolder(orgId1, "lionfish")
+	assert.NoError(t, err, "unexpected error")
+
+	// searches only read the driver, so they can share it.
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result, err := f.SearchByName(orgId1, "lion", folder.SearchFuzzy)
+			assert.NoError(t, err, "unexpected error")
+			assert.Len(t, result, 7, "unexpected results")
+		}()
+	}
+	wg.Wait()
+}
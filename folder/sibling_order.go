@@ -0,0 +1,141 @@
+package folder
+
+import (
+	"slices"
+
+	"github.com/gofrs/uuid"
+)
+
+// the moves here, like MoveFolder, only change the driver's tree if it is
+// stateful, and otherwise return a moved copy. ReorderChildren always changes
+// it. both give every child of the parent they put a folder under an
+// explicit Position.
+
+func (d *driver) MoveFolderToIndex(
+	orgID uuid.UUID,
+	name string,
+	dst string,
+	index int,
+) ([]Folder, error) {
+	if name == "" || dst == "" {
+		return nil, ErrInvalidArguments
+	}
+
+	srcNode, dstNode, err := d.lookupMoveInOrg(orgID, name, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.validateMove(srcNode, dstNode); err != nil {
+		return nil, err
+	}
+
+	// the index is among dst's children once src has been taken out.
+	maxIndex := len(dstNode.Children)
+	if srcNode.Parent == dstNode {
+		maxIndex--
+	}
+
+	if index < 0 || index > maxIndex {
+		return nil, ErrInvalidPosition
+	}
+
+	return foldersInOrg(d.moveNodeToIndex(srcNode, dstNode, index), orgID), nil
+}
+
+func (d *driver) MoveFolderBefore(orgID uuid.UUID, name string, sibling string) ([]Folder, error) {
+	return d.moveFolderNextTo(orgID, name, sibling, 0)
+}
+
+func (d *driver) MoveFolderAfter(orgID uuid.UUID, name string, sibling string) ([]Folder, error) {
+	return d.moveFolderNextTo(orgID, name, sibling, 1)
+}
+
+// moves name under sibling's parent, offset places after sibling.
+func (d *driver) moveFolderNextTo(orgID uuid.UUID, name string, sibling string, offset int) ([]Folder, error) {
+	if name == "" || sibling == "" {
+		return nil, ErrInvalidArguments
+	}
+
+	srcNode, siblingNode, err := d.lookupMoveInOrg(orgID, name, sibling)
+	if err != nil {
+		return nil, err
+	}
+
+	if srcNode == siblingNode {
+		return nil, ErrMoveToSource
+	}
+
+	// only children have an order, roots keep input order.
+	dstNode := siblingNode.Parent
+	if dstNode == nil {
+		return nil, ErrFolderHasNoParent
+	}
+
+	if err := d.validateMove(srcNode, dstNode); err != nil {
+		return nil, err
+	}
+
+	// indexes are counted once src has been taken out.
+	index := slices.Index(dstNode.Children, siblingNode) + offset
+	if srcNode.Parent == dstNode && slices.Index(dstNode.Children, srcNode) < index {
+		index--
+	}
+
+	return foldersInOrg(d.moveNodeToIndex(srcNode, dstNode, index), orgID), nil
+}
+
+// moves srcNode to be the child at index of dstNode, applying the move to
+// the tree only if the driver is stateful.
+func (d *driver) moveNodeToIndex(srcNode *FolderNode, dstNode *FolderNode, index int) []Folder {
+	if !d.stateful {
+		return moveFolderAndChildren(d, srcNode, dstNode, index)
+	}
+
+	detachNode(srcNode)
+	insertNode(srcNode, dstNode, index)
+	rebasePaths(srcNode, movedPath(srcNode, dstNode))
+	d.version++
+
+	return d.allFolders()
+}
+
+func (d *driver) ReorderChildren(orgID uuid.UUID, parentName string, names []string) ([]Folder, error) {
+	parentNode, err := d.lookupInOrg(orgID, parentName)
+	if err != nil {
+		return nil, err
+	}
+
+	// sibling names are unique within an org, so each name picks one child.
+	byName := map[string]*FolderNode{}
+	others := []*FolderNode{}
+	for _, child := range parentNode.Children {
+		if child.Folder.OrgId == orgID {
+			byName[child.Folder.Name] = child
+		} else {
+			others = append(others, child)
+		}
+	}
+
+	if len(names) != len(byName) {
+		return nil, ErrInvalidChildOrder
+	}
+
+	children := []*FolderNode{}
+	for _, name := range names {
+		child, ok := byName[name]
+		if !ok {
+			return nil, ErrInvalidChildOrder
+		}
+
+		children = append(children, child)
+		delete(byName, name)
+	}
+
+	// children from other orgs aren't the caller's to order, so they go last.
+	parentNode.Children = append(children, others...)
+	numberChildren(parentNode)
+	d.version++
+
+	return d.GetChildren(orgID, parentName)
+}
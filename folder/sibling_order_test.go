@@ -0,0 +1,312 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func orderFolders(orgId uuid.UUID) []folder.Folder {
+	return []folder.Folder{
+		{Name: "alpha", OrgId: orgId, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId, Paths: "alpha.charlie"},
+		{Name: "delta", OrgId: orgId, Paths: "alpha.delta"},
+		{Name: "echo", OrgId: orgId, Paths: "echo"},
+		{Name: "foxtrot", OrgId: orgId, Paths: "echo.foxtrot"},
+	}
+}
+
+// names and positions of a folder's children, in order.
+func childOrder(t *testing.T, f folder.IDriver, orgId uuid.UUID, name string) ([]string, []int) {
+	children, err := f.GetChildren(orgId, name)
+	assert.NoError(t, err, "unexpected error")
+
+	names, positions := []string{}, []int{}
+	for _, child := range children {
+		names = append(names, child.Name)
+		positions = append(positions, child.Position)
+	}
+
+	return names, positions
+}
+
+func Test_folder_SiblingOrder(t *testing.T) {
+	t.Parallel()
+
+	orgId := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	tests := [...]struct {
+		testName        string
+		op              func(folder.IDriver) ([]folder.Folder, error)
+		expectOrder     []string
+		expectPositions []int
+		// changes the tree even without stateful moves
+		alwaysApplied bool
+		expectError   error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName: "Move to the front of another folder.",
+			op: func(f folder.IDriver) ([]folder.Folder, error) {
+				return f.MoveFolderToIndex(orgId, "foxtrot", "alpha", 0)
+			},
+			expectOrder:     []string{"foxtrot", "bravo", "charlie", "delta"},
+			expectPositions: []int{1, 2, 3, 4},
+		},
+
+		{
+			testName: "Move to the front of the same folder.",
+			op: func(f folder.IDriver) ([]folder.Folder, error) {
+				return f.MoveFolderToIndex(orgId, "delta", "alpha", 0)
+			},
+			expectOrder:     []string{"delta", "bravo", "charlie"},
+			expectPositions: []int{1, 2, 3},
+		},
+
+		{
+			testName: "Move to the end of the same folder.",
+			op: func(f folder.IDriver) ([]folder.Folder, error) {
+				return f.MoveFolderToIndex(orgId, "bravo", "alpha", 2)
+			},
+			expectOrder:     []string{"charlie", "delta", "bravo"},
+			expectPositions: []int{1, 2, 3},
+		},
+
+		{
+			testName: "Move before a sibling in another folder.",
+			op: func(f folder.IDriver) ([]folder.Folder, error) {
+				return f.MoveFolderBefore(orgId, "foxtrot", "charlie")
+			},
+			expectOrder:     []string{"bravo", "foxtrot", "charlie", "delta"},
+			expectPositions: []int{1, 2, 3, 4},
+		},
+
+		{
+			testName: "Move before an earlier sibling.",
+			op: func(f folder.IDriver) ([]folder.Folder, error) {
+				return f.MoveFolderBefore(orgId, "delta", "bravo")
+			},
+			expectOrder:     []string{"delta", "bravo", "charlie"},
+			expectPositions: []int{1, 2, 3},
+		},
+
+		{
+			testName: "Move after a later sibling.",
+			op: func(f folder.IDriver) ([]folder.Folder, error) {
+				return f.MoveFolderAfter(orgId, "bravo", "delta")
+			},
+			expectOrder:     []string{"charlie", "delta", "bravo"},
+			expectPositions: []int{1, 2, 3},
+		},
+
+		{
+			testName: "Reorder children.",
+			op: func(f folder.IDriver) ([]folder.Folder, error) {
+				return f.ReorderChildren(orgId, "alpha", []string{"delta", "bravo", "charlie"})
+			},
+			expectOrder:     []string{"delta", "bravo", "charlie"},
+			expectPositions: []int{1, 2, 3},
+			alwaysApplied:   true,
+		},
+
+		//-------- errorful cases
+
+		{
+			testName: "Index past the end.",
+			op: func(f folder.IDriver) ([]folder.Folder, error) {
+				return f.MoveFolderToIndex(orgId, "foxtrot", "alpha", 4)
+			},
+			expectError: folder.ErrInvalidPosition,
+		},
+
+		{
+			testName: "Negative index.",
+			op: func(f folder.IDriver) ([]folder.Folder, error) {
+				return f.MoveFolderToIndex(orgId, "foxtrot", "alpha", -1)
+			},
+			expectError: folder.ErrInvalidPosition,
+		},
+
+		{
+			testName: "Sibling is a root.",
+			op: func(f folder.IDriver) ([]folder.Folder, error) {
+				return f.MoveFolderBefore(orgId, "foxtrot", "alpha")
+			},
+			expectError: folder.ErrFolderHasNoParent,
+		},
+
+		{
+			testName: "Move next to itself.",
+			op: func(f folder.IDriver) ([]folder.Folder, error) {
+				return f.MoveFolderAfter(orgId, "bravo", "bravo")
+			},
+			expectError: folder.ErrMoveToSource,
+		},
+
+		{
+			testName: "Move next to its own child.",
+			op: func(f folder.IDriver) ([]folder.Folder, error) {
+				return f.MoveFolderBefore(orgId, "alpha", "bravo")
+			},
+			expectError: folder.ErrMoveToSource,
+		},
+
+		{
+			testName: "Reorder missing a child.",
+			op: func(f folder.IDriver) ([]folder.Folder, error) {
+				return f.ReorderChildren(orgId, "alpha", []string{"delta", "bravo"})
+			},
+			expectError: folder.ErrInvalidChildOrder,
+		},
+
+		{
+			testName: "Reorder repeating a child.",
+			op: func(f folder.IDriver) ([]folder.Folder, error) {
+				return f.ReorderChildren(orgId, "alpha", []string{"delta", "bravo", "bravo"})
+			},
+			expectError: folder.ErrInvalidChildOrder,
+		},
+
+		{
+			testName: "Reorder with a folder that isnt a child.",
+			op: func(f folder.IDriver) ([]folder.Folder, error) {
+				return f.ReorderChildren(orgId, "alpha", []string{"delta", "bravo", "foxtrot"})
+			},
+			expectError: folder.ErrInvalidChildOrder,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(orderFolders(orgId), folder.WithStatefulMoves())
+			assert.NoError(t, err, "unexpected error")
+
+			stateless, err := folder.NewDriver(orderFolders(orgId))
+			assert.NoError(t, err, "unexpected error")
+
+			result, err := tc.op(f)
+			statelessResult, statelessErr := tc.op(stateless)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+				assert.ErrorIs(t, statelessErr, tc.expectError, "expected error")
+
+				for _, driver := range []folder.IDriver{f, stateless} {
+					names, positions := childOrder(t, driver, orgId, "alpha")
+					assert.Equal(t, []string{"bravo", "charlie", "delta"}, names, "tree changed")
+					assert.Equal(t, []int{0, 0, 0}, positions, "tree changed")
+				}
+				return
+			}
+
+			assert.NoError(t, err, "unexpected error")
+			names, positions := childOrder(t, f, orgId, "alpha")
+			assert.Equal(t, tc.expectOrder, names, "unexpected order")
+			assert.Equal(t, tc.expectPositions, positions, "unexpected positions")
+
+			// a stateless driver returns the same folders, but only
+			// ReorderChildren changes its tree.
+			assert.NoError(t, statelessErr, "unexpected error")
+			assert.Equal(t, result, statelessResult, "stateless result differs")
+
+			names, positions = childOrder(t, stateless, orgId, "alpha")
+			if tc.alwaysApplied {
+				assert.Equal(t, tc.expectOrder, names, "unexpected order")
+				assert.Equal(t, tc.expectPositions, positions, "unexpected positions")
+			} else {
+				assert.Equal(t, []string{"bravo", "charlie", "delta"}, names, "stateless tree changed")
+				assert.Equal(t, []int{0, 0, 0}, positions, "stateless tree changed")
+			}
+
+			// the order survives a round trip through the folders.
+			folders, err := f.GetFoldersByOrgID(orgId)
+			assert.NoError(t, err, "unexpected error")
+
+			reloaded, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			names, _ = childOrder(t, reloaded, orgId, "alpha")
+			assert.Equal(t, tc.expectOrder, names, "order not persisted")
+		})
+	}
+}
+
+func Test_folder_SiblingOrder_Positions(t *testing.T) {
+	t.Parallel()
+
+	orgId := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	t.Run("Input positions decide the order.", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := folder.NewDriver([]folder.Folder{
+			{Name: "alpha", OrgId: orgId, Paths: "alpha"},
+			{Name: "bravo", OrgId: orgId, Paths: "alpha.bravo"},
+			{Name: "charlie", OrgId: orgId, Paths: "alpha.charlie", Position: 2},
+			{Name: "delta", OrgId: orgId, Paths: "alpha.delta", Position: 1},
+		})
+		assert.NoError(t, err, "unexpected error")
+
+		names, positions := childOrder(t, f, orgId, "alpha")
+		assert.Equal(t, []string{"delta", "charlie", "bravo"}, names, "unexpected order")
+		assert.Equal(t, []int{1, 2, 0}, positions, "unexpected positions")
+	})
+
+	t.Run("Moves into ordered folders are appended with a position.", func(t *testing.T) {
+		t.Parallel()
+
+		stateful, err := folder.NewDriver(orderFolders(orgId), folder.WithStatefulMoves())
+		assert.NoError(t, err, "unexpected error")
+
+		_, err = stateful.ReorderChildren(orgId, "alpha", []string{"delta", "charlie", "bravo"})
+		assert.NoError(t, err, "unexpected error")
+
+		stateless, err := folder.NewDriver(orderFolders(orgId))
+		assert.NoError(t, err, "unexpected error")
+
+		_, err = stateless.ReorderChildren(orgId, "alpha", []string{"delta", "charlie", "bravo"})
+		assert.NoError(t, err, "unexpected error")
+
+		moved, err := stateful.MoveFolder("foxtrot", "alpha")
+		assert.NoError(t, err, "unexpected error")
+
+		names, positions := childOrder(t, stateful, orgId, "alpha")
+		assert.Equal(t, []string{"delta", "charlie", "bravo", "foxtrot"}, names, "unexpected order")
+		assert.Equal(t, []int{1, 2, 3, 4}, positions, "unexpected positions")
+
+		// a stateless move reports the same positions without applying them.
+		copied, err := stateless.MoveFolder("foxtrot", "alpha")
+		assert.NoError(t, err, "unexpected error")
+		assert.Equal(t, moved, copied, "stateless move differs")
+	})
+
+	t.Run("Moving out of an ordered folder closes the gap.", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := folder.NewDriver(orderFolders(orgId), folder.WithStatefulMoves())
+		assert.NoError(t, err, "unexpected error")
+
+		_, err = f.ReorderChildren(orgId, "alpha", []string{"delta", "charlie", "bravo"})
+		assert.NoError(t, err, "unexpected error")
+
+		_, err = f.MoveFolder("charlie", "echo")
+		assert.NoError(t, err, "unexpected error")
+
+		names, positions := childOrder(t, f, orgId, "alpha")
+		assert.Equal(t, []string{"delta", "bravo"}, names, "unexpected order")
+		assert.Equal(t, []int{1, 2}, positions, "unexpected positions")
+
+		names, positions = childOrder(t, f, orgId, "echo")
+		assert.Equal(t, []string{"foxtrot", "charlie"}, names, "unexpected order")
+		assert.Equal(t, []int{0, 0}, positions, "unexpected positions")
+	})
+}
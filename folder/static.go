@@ -30,9 +30,13 @@ const MaxDepth = 5
 const DefaultOrgID = "c1556e17-b7c0-45a3-a6ae-9546248fb17a"
 
 type Folder struct {
+	ID    uuid.UUID `json:"id"`
 	Name  string    `json:"name"`
 	OrgId uuid.UUID `json:"org_id"`
 	Paths string    `json:"paths"`
+	// 1-based place among its siblings. 0 means unpositioned, which sorts
+	// after positioned siblings and otherwise keeps input order.
+	Position int `json:"position,omitempty"`
 }
 
 func GenerateData() []Folder {
@@ -51,6 +55,7 @@ func GenerateData() []Folder {
 		go func() {
 			subtree <- generateTree(1, []Folder{
 				{
+					ID:    uuid.Must(uuid.NewV4()),
 					Name:  name,
 					OrgId: orgId,
 					Paths: name,
@@ -79,6 +84,7 @@ func generateTree(depth int, tree []Folder) []Folder {
 			go func() {
 				childTree <- generateTree(depth+1, []Folder{
 					{
+						ID:    uuid.Must(uuid.NewV4()),
 						Name:  name,
 						OrgId: t.OrgId,
 						Paths: t.Paths + "." + name,
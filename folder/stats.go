@@ -0,0 +1,81 @@
+package folder
+
+import "github.com/gofrs/uuid"
+
+// SubtreeStats describes the shape of the subtree rooted at a folder. only
+// folders in the same org as the root are counted, but folders of other orgs
+// are looked through, as GetAllChildFolders does, and still count as levels.
+type SubtreeStats struct {
+	// number of folders below the root, not including the root itself
+	Descendants int
+	// number of levels on the longest path from the root down to a folder
+	Height int
+	// number of folders with no descendants, the root counts if it has none
+	Leaves int
+	// largest number of direct children of a single folder
+	MaxFanOut int
+	// mean number of direct children over folders that have any
+	AvgFanOut float64
+	// DepthCounts[i] is the number of folders i levels below the root
+	DepthCounts []int
+}
+
+func (d *driver) Stats(orgID uuid.UUID, name string) (SubtreeStats, error) {
+	node, err := d.lookupInOrg(orgID, name)
+	if err != nil {
+		return SubtreeStats{}, err
+	}
+
+	stats := SubtreeStats{}
+	parents, edges := 0, 0
+	collectStats(&stats, node, orgID, 0, &parents, &edges)
+
+	if parents > 0 {
+		stats.AvgFanOut = float64(edges) / float64(parents)
+	}
+
+	return stats, nil
+}
+
+// adds node's subtree to stats, reporting whether it holds any folder of orgID.
+func collectStats(stats *SubtreeStats, node *FolderNode, orgID uuid.UUID, depth int, parents *int, edges *int) bool {
+	counted := node.Folder.OrgId == orgID
+	if counted {
+		for len(stats.DepthCounts) <= depth {
+			stats.DepthCounts = append(stats.DepthCounts, 0)
+		}
+		stats.DepthCounts[depth]++
+		stats.Height = max(stats.Height, depth)
+
+		if depth > 0 {
+			stats.Descendants++
+		}
+	}
+
+	fanOut, below := 0, false
+	for _, child := range node.Children {
+		if collectStats(stats, child, orgID, depth+1, parents, edges) {
+			below = true
+		}
+
+		if child.Folder.OrgId == orgID {
+			fanOut++
+		}
+	}
+
+	if !counted {
+		return below
+	}
+
+	if !below {
+		stats.Leaves++
+	}
+
+	if fanOut > 0 {
+		*parents++
+		*edges += fanOut
+		stats.MaxFanOut = max(stats.MaxFanOut, fanOut)
+	}
+
+	return true
+}
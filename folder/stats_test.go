@@ -0,0 +1,160 @@
+package folder_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_folder_Stats(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId1, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: orgId1, Paths: "alpha.bravo.delta"},
+		{Name: "echo", OrgId: orgId1, Paths: "alpha.bravo.echo"},
+		{Name: "foxtrot", OrgId: orgId1, Paths: "alpha.foxtrot"},
+		{Name: "golf", OrgId: orgId1, Paths: "alpha.foxtrot.golf"},
+		{Name: "hotel", OrgId: orgId1, Paths: "alpha.hotel"},
+		{Name: "india", OrgId: orgId2, Paths: "india"},
+		{Name: "juliet", OrgId: orgId1, Paths: "juliet"},
+		{Name: "kilo", OrgId: orgId2, Paths: "juliet.kilo"},
+		{Name: "lima", OrgId: orgId1, Paths: "juliet.kilo.lima"},
+		{Name: "mike", OrgId: orgId1, Paths: "juliet.kilo.lima.mike"},
+	}
+
+	tests := [...]struct {
+		testName    string
+		orgID       uuid.UUID
+		name        string
+		expect      folder.SubtreeStats
+		expectError error
+	}{
+
+		//-------- non-error cases
+
+		{
+			testName: "Whole tree.",
+			orgID:    orgId1,
+			name:     "alpha",
+			expect: folder.SubtreeStats{
+				Descendants: 7,
+				Height:      2,
+				Leaves:      5,
+				MaxFanOut:   3,
+				AvgFanOut:   7.0 / 3.0,
+				DepthCounts: []int{1, 3, 4},
+			},
+		},
+
+		{
+			testName: "Inner subtree.",
+			orgID:    orgId1,
+			name:     "foxtrot",
+			expect: folder.SubtreeStats{
+				Descendants: 1,
+				Height:      1,
+				Leaves:      1,
+				MaxFanOut:   1,
+				AvgFanOut:   1,
+				DepthCounts: []int{1, 1},
+			},
+		},
+
+		{
+			testName: "Leaf.",
+			orgID:    orgId1,
+			name:     "hotel",
+			expect: folder.SubtreeStats{
+				Leaves:      1,
+				DepthCounts: []int{1},
+			},
+		},
+
+		{
+			testName: "Looks through other orgs.",
+			orgID:    orgId1,
+			name:     "juliet",
+			expect: folder.SubtreeStats{
+				Descendants: 2,
+				Height:      3,
+				Leaves:      1,
+				MaxFanOut:   1,
+				AvgFanOut:   1,
+				DepthCounts: []int{1, 0, 1, 1},
+			},
+		},
+
+		//-------- errorful cases
+
+		{
+			testName:    "Folder doesnt exist.",
+			orgID:       orgId1,
+			name:        "x",
+			expectError: folder.ErrFolderDoesNotExist,
+		},
+
+		{
+			testName:    "Folder in another org.",
+			orgID:       orgId1,
+			name:        "india",
+			expectError: folder.ErrFolderDoesNotExistInOrg,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := folder.NewDriver(folders)
+			assert.NoError(t, err, "unexpected error")
+
+			result, err := f.Stats(tc.orgID, tc.name)
+
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+				assert.Equal(t, tc.expect, result, "unexpected stats")
+
+				descendants, err := f.GetAllChildFolders(tc.orgID, tc.name)
+				assert.NoError(t, err, "unexpected error")
+				assert.Len(t, descendants, result.Descendants, "descendants differ from GetAllChildFolders")
+			}
+		})
+	}
+}
+
+func Test_folder_Stats_GenerateData(t *testing.T) {
+	t.Parallel()
+
+	folders := folder.GenerateData()
+	f, err := folder.NewDriver(folders)
+	assert.NoError(t, err, "unexpected error")
+
+	for _, root := range folders {
+		if strings.Contains(root.Paths, ".") {
+			continue
+		}
+
+		stats, err := f.Stats(root.OrgId, root.Name)
+		if errors.Is(err, folder.ErrAmbiguousFolderName) {
+			// generated names can occasionally collide within an org
+			continue
+		}
+		assert.NoError(t, err, "unexpected error")
+		assert.LessOrEqual(t, stats.Height, folder.MaxDepth-1, "tree too deep")
+		assert.LessOrEqual(t, stats.MaxFanOut, folder.MaxChild, "too many children")
+	}
+}
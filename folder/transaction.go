@@ -0,0 +1,366 @@
+package folder
+
+import (
+	"iter"
+
+	"github.com/gofrs/uuid"
+)
+
+// Tx is a transaction started by Begin. every IDriver method on a Tx works on
+// the transaction's own copy of the folders, with moves always applied in
+// place, so its changes stay invisible to the driver until Commit.
+// once committed or rolled back, every method fails with ErrTxClosed, except
+// ListOrgs which returns nil and Begin which returns a closed Tx.
+type Tx interface {
+	IDriver
+	// Commit publishes the transaction's changes to the driver. it fails with
+	// ErrTxConflict, publishing nothing, if the driver changed since Begin.
+	Commit() error
+	// Rollback discards the transaction's changes.
+	Rollback() error
+}
+
+type tx struct {
+	// the transaction's copy of the parent's folders
+	driver *driver
+
+	parent      *driver
+	baseVersion int
+	closed      bool
+}
+
+func (d *driver) Begin() Tx {
+	c := d.clone()
+	c.stateful = true
+
+	return &tx{
+		driver:      c,
+		parent:      d,
+		baseVersion: d.version,
+	}
+}
+
+func (t *tx) Commit() error {
+	if t.closed {
+		return ErrTxClosed
+	}
+	t.closed = true
+
+	if t.parent.version != t.baseVersion {
+		return ErrTxConflict
+	}
+
+	t.parent.adopt(t.driver)
+	t.driver = nil
+
+	return nil
+}
+
+func (t *tx) Rollback() error {
+	if t.closed {
+		return ErrTxClosed
+	}
+	t.closed = true
+	t.driver = nil
+
+	return nil
+}
+
+// the IDriver methods below pass through to the transaction's driver while
+// the transaction is open.
+
+func (t *tx) ListOrgs() []OrgSummary {
+	if t.closed {
+		return nil
+	}
+
+	return t.driver.ListOrgs()
+}
+
+func (t *tx) Begin() Tx {
+	if t.closed {
+		return &tx{closed: true}
+	}
+
+	return t.driver.Begin()
+}
+
+func (t *tx) GetFoldersByOrgID(orgID uuid.UUID) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.GetFoldersByOrgID(orgID)
+}
+
+func (t *tx) GetAllChildFolders(orgID uuid.UUID, name string) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.GetAllChildFolders(orgID, name)
+}
+
+func (t *tx) GetAllChildFoldersByID(orgID uuid.UUID, id uuid.UUID) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.GetAllChildFoldersByID(orgID, id)
+}
+
+func (t *tx) GetFoldersByOrgIDPage(orgID uuid.UUID, opts PageOptions) (Page, error) {
+	if t.closed {
+		return Page{}, ErrTxClosed
+	}
+
+	return t.driver.GetFoldersByOrgIDPage(orgID, opts)
+}
+
+func (t *tx) GetAllChildFoldersPage(orgID uuid.UUID, name string, opts PageOptions) (Page, error) {
+	if t.closed {
+		return Page{}, ErrTxClosed
+	}
+
+	return t.driver.GetAllChildFoldersPage(orgID, name, opts)
+}
+
+func (t *tx) GetChildren(orgID uuid.UUID, name string) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.GetChildren(orgID, name)
+}
+
+func (t *tx) GetDescendants(orgID uuid.UUID, name string, maxDepth int) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.GetDescendants(orgID, name, maxDepth)
+}
+
+func (t *tx) GetParent(orgID uuid.UUID, name string) (Folder, error) {
+	if t.closed {
+		return Folder{}, ErrTxClosed
+	}
+
+	return t.driver.GetParent(orgID, name)
+}
+
+func (t *tx) GetAncestors(orgID uuid.UUID, name string) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.GetAncestors(orgID, name)
+}
+
+func (t *tx) GetSiblings(orgID uuid.UUID, name string) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.GetSiblings(orgID, name)
+}
+
+func (t *tx) IterPreOrder(orgID uuid.UUID, name string) (iter.Seq[Folder], error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.IterPreOrder(orgID, name)
+}
+
+func (t *tx) IterPostOrder(orgID uuid.UUID, name string) (iter.Seq[Folder], error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.IterPostOrder(orgID, name)
+}
+
+func (t *tx) IterBreadthFirst(orgID uuid.UUID, name string) (iter.Seq[Folder], error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.IterBreadthFirst(orgID, name)
+}
+
+func (t *tx) IterAncestors(orgID uuid.UUID, name string) (iter.Seq[Folder], error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.IterAncestors(orgID, name)
+}
+
+func (t *tx) LowestCommonAncestor(orgID uuid.UUID, a string, b string) (Folder, error) {
+	if t.closed {
+		return Folder{}, ErrTxClosed
+	}
+
+	return t.driver.LowestCommonAncestor(orgID, a, b)
+}
+
+func (t *tx) PathBetween(orgID uuid.UUID, a string, b string) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.PathBetween(orgID, a, b)
+}
+
+func (t *tx) Stats(orgID uuid.UUID, name string) (SubtreeStats, error) {
+	if t.closed {
+		return SubtreeStats{}, ErrTxClosed
+	}
+
+	return t.driver.Stats(orgID, name)
+}
+
+func (t *tx) Match(orgID uuid.UUID, pattern string) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.Match(orgID, pattern)
+}
+
+func (t *tx) MatchText(orgID uuid.UUID, query string) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.MatchText(orgID, query)
+}
+
+func (t *tx) SearchByName(orgID uuid.UUID, query string, mode SearchMode) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.SearchByName(orgID, query, mode)
+}
+
+func (t *tx) MoveFolder(name string, dst string) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.MoveFolder(name, dst)
+}
+
+func (t *tx) MoveFolderByID(id uuid.UUID, dstID uuid.UUID) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.MoveFolderByID(id, dstID)
+}
+
+func (t *tx) MoveFolderToRoot(name string) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.MoveFolderToRoot(name)
+}
+
+func (t *tx) MoveFolderInOrg(orgID uuid.UUID, name string, dst string) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.MoveFolderInOrg(orgID, name, dst)
+}
+
+func (t *tx) ApplyMoves(moves []Move) ([]Folder, []MoveOutcome, error) {
+	if t.closed {
+		return nil, nil, ErrTxClosed
+	}
+
+	return t.driver.ApplyMoves(moves)
+}
+
+func (t *tx) PreviewMove(move Move) (MovePreview, error) {
+	if t.closed {
+		return MovePreview{}, ErrTxClosed
+	}
+
+	return t.driver.PreviewMove(move)
+}
+
+func (t *tx) MoveFolderToIndex(orgID uuid.UUID, name string, dst string, index int) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.MoveFolderToIndex(orgID, name, dst, index)
+}
+
+func (t *tx) MoveFolderBefore(orgID uuid.UUID, name string, sibling string) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.MoveFolderBefore(orgID, name, sibling)
+}
+
+func (t *tx) MoveFolderAfter(orgID uuid.UUID, name string, sibling string) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.MoveFolderAfter(orgID, name, sibling)
+}
+
+func (t *tx) ReorderChildren(orgID uuid.UUID, parentName string, names []string) ([]Folder, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.ReorderChildren(orgID, parentName, names)
+}
+
+func (t *tx) CreateFolder(orgID uuid.UUID, parentName string, name string) (Folder, error) {
+	if t.closed {
+		return Folder{}, ErrTxClosed
+	}
+
+	return t.driver.CreateFolder(orgID, parentName, name)
+}
+
+func (t *tx) CreateRootFolder(orgID uuid.UUID, name string) (Folder, error) {
+	if t.closed {
+		return Folder{}, ErrTxClosed
+	}
+
+	return t.driver.CreateRootFolder(orgID, name)
+}
+
+func (t *tx) DeleteFolder(orgID uuid.UUID, name string, strategy DeleteStrategy) (DeleteResult, error) {
+	if t.closed {
+		return DeleteResult{}, ErrTxClosed
+	}
+
+	return t.driver.DeleteFolder(orgID, name, strategy)
+}
+
+func (t *tx) RenameFolder(orgID uuid.UUID, oldName string, newName string) ([]PathChange, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.RenameFolder(orgID, oldName, newName)
+}
+
+func (t *tx) CopyFolder(name string, dst string, namer Namer) ([]FolderCopy, error) {
+	if t.closed {
+		return nil, ErrTxClosed
+	}
+
+	return t.driver.CopyFolder(name, dst, namer)
+}
@@ -0,0 +1,149 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_folder_Transaction(t *testing.T) {
+	t.Parallel()
+
+	orgId := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId, Paths: "alpha"},
+		{Name: "bravo", OrgId: orgId, Paths: "alpha.bravo"},
+		{Name: "charlie", OrgId: orgId, Paths: "alpha.bravo.charlie"},
+		{Name: "delta", OrgId: orgId, Paths: "delta"},
+	}
+
+	paths := func(t *testing.T, f folder.IDriver) []string {
+		res, err := f.GetFoldersByOrgID(orgId)
+		assert.NoError(t, err, "unexpected error")
+
+		paths := []string{}
+		for _, f := range res {
+			paths = append(paths, f.Paths)
+		}
+		return paths
+	}
+
+	// a create, move, rename and delete, leaving the paths below.
+	edit := func(t *testing.T, tx folder.Tx) {
+		_, err := tx.CreateFolder(orgId, "delta", "echo")
+		assert.NoError(t, err, "unexpected error")
+
+		_, err = tx.MoveFolder("bravo", "delta")
+		assert.NoError(t, err, "unexpected error")
+
+		_, err = tx.RenameFolder(orgId, "charlie", "chuck")
+		assert.NoError(t, err, "unexpected error")
+
+		_, err = tx.DeleteFolder(orgId, "alpha", folder.DeleteRefuseIfNonEmpty)
+		assert.NoError(t, err, "unexpected error")
+	}
+	before := []string{"alpha", "alpha.bravo", "alpha.bravo.charlie", "delta"}
+	edited := []string{"delta.bravo", "delta.bravo.chuck", "delta", "delta.echo"}
+
+	t.Run("Commit publishes changes.", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := folder.NewDriver(folders)
+		assert.NoError(t, err, "unexpected error")
+
+		tx := f.Begin()
+		edit(t, tx)
+
+		assert.Equal(t, edited, paths(t, tx), "changes not visible in transaction")
+		assert.Equal(t, before, paths(t, f), "changes visible outside transaction")
+
+		assert.NoError(t, tx.Commit(), "unexpected error")
+		assert.Equal(t, edited, paths(t, f), "changes not published")
+
+		_, err = f.GetAllChildFolders(orgId, "chuck")
+		assert.NoError(t, err, "renamed folder not found")
+	})
+
+	t.Run("Rollback discards changes.", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := folder.NewDriver(folders)
+		assert.NoError(t, err, "unexpected error")
+
+		tx := f.Begin()
+		edit(t, tx)
+
+		assert.NoError(t, tx.Rollback(), "unexpected error")
+		assert.Equal(t, before, paths(t, f), "changes published")
+	})
+
+	t.Run("Closed transactions cant be reused.", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := folder.NewDriver(folders)
+		assert.NoError(t, err, "unexpected error")
+
+		committed := f.Begin()
+		assert.NoError(t, committed.Commit(), "unexpected error")
+		assert.ErrorIs(t, committed.Commit(), folder.ErrTxClosed, "expected error")
+		assert.ErrorIs(t, committed.Rollback(), folder.ErrTxClosed, "expected error")
+
+		rolledBack := f.Begin()
+		assert.NoError(t, rolledBack.Rollback(), "unexpected error")
+		assert.ErrorIs(t, rolledBack.Commit(), folder.ErrTxClosed, "expected error")
+
+		// every method of a closed transaction fails.
+		for _, tx := range []folder.Tx{committed, rolledBack} {
+			_, err = tx.CreateRootFolder(orgId, "echo")
+			assert.ErrorIs(t, err, folder.ErrTxClosed, "expected error")
+
+			_, err = tx.MoveFolder("bravo", "delta")
+			assert.ErrorIs(t, err, folder.ErrTxClosed, "expected error")
+
+			_, err = tx.GetFoldersByOrgID(orgId)
+			assert.ErrorIs(t, err, folder.ErrTxClosed, "expected error")
+
+			_, err = tx.Begin().GetAllChildFolders(orgId, "alpha")
+			assert.ErrorIs(t, err, folder.ErrTxClosed, "expected error")
+
+			assert.Nil(t, tx.ListOrgs(), "closed transaction listed orgs")
+		}
+		assert.Equal(t, before, paths(t, f), "closed transaction changed the driver")
+	})
+
+	t.Run("Commit fails if the driver changed.", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := folder.NewDriver(folders)
+		assert.NoError(t, err, "unexpected error")
+
+		first, second := f.Begin(), f.Begin()
+		edit(t, first)
+		edit(t, second)
+
+		assert.NoError(t, first.Commit(), "unexpected error")
+		assert.ErrorIs(t, second.Commit(), folder.ErrTxConflict, "expected error")
+		assert.Equal(t, edited, paths(t, f), "conflicting transaction published")
+
+		tx := f.Begin()
+		_, err = f.CreateRootFolder(orgId, "foxtrot")
+		assert.NoError(t, err, "unexpected error")
+		assert.ErrorIs(t, tx.Commit(), folder.ErrTxConflict, "expected error")
+	})
+
+	t.Run("Stateful moves outside a transaction conflict.", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := folder.NewDriver(folders, folder.WithStatefulMoves())
+		assert.NoError(t, err, "unexpected error")
+
+		tx := f.Begin()
+		_, err = f.MoveFolder("bravo", "delta")
+		assert.NoError(t, err, "unexpected error")
+
+		assert.ErrorIs(t, tx.Commit(), folder.ErrTxConflict, "expected error")
+	})
+}
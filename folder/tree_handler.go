@@ -1,35 +1,353 @@
 package folder
 
-import "strings"
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
 
-func BuildFolderTree(folders []Folder, nameOfFolders []string) (map[string]*FolderNode, error) {
-	nameToNode := make(map[string]*FolderNode)
+	"github.com/gofrs/uuid"
+)
 
-	// assumption: the names of the files are unique.
-	// populate map with nodes
-	for i, f := range folders {
-		nameToNode[f.Name] = &FolderNode{Folder: &folders[i]}
+// BuildFolderTree links folders into a tree, returning their nodes in input order.
+// Folder names may repeat, so parents are found by their full path: a folder in
+// the same org is preferred, otherwise the parent path must match a single folder.
+// Folders whose parent can't be found are left unlinked.
+// Children are ordered by Position, with unpositioned children after the rest
+// in input order.
+func BuildFolderTree(folders []Folder) ([]*FolderNode, error) {
+	type pathKey struct {
+		orgID uuid.UUID
+		path  string
 	}
 
-	// populate the existing nodes with children
-	for _, name := range nameOfFolders {
-		node, ok := nameToNode[name]
-		if !ok {
-			continue
+	nodes := make([]*FolderNode, len(folders))
+	pathToNode := map[pathKey]*FolderNode{}
+	pathToNodes := map[string][]*FolderNode{}
+	ids := map[uuid.UUID]bool{}
+
+	// populate the maps with nodes
+	for i := range folders {
+		node := &FolderNode{Folder: &folders[i]}
+		key := pathKey{orgID: node.Folder.OrgId, path: node.Folder.Paths}
+
+		if _, exists := pathToNode[key]; exists {
+			return nil, ErrDuplicateFolderPath
 		}
 
-		parts := strings.Split(node.Folder.Paths, ".")
+		if id := node.Folder.ID; id != uuid.Nil {
+			if ids[id] {
+				return nil, ErrDuplicateFolderID
+			}
+			ids[id] = true
+		}
+
+		nodes[i] = node
+		pathToNode[key] = node
+		pathToNodes[key.path] = append(pathToNodes[key.path], node)
+	}
 
-		if len(parts) <= 1 {
+	// populate the existing nodes with children
+	for _, node := range nodes {
+		parent := parentPath(node.Folder.Paths)
+		if parent == "" {
 			continue
 		}
 
-		parentName := parts[len(parts)-2]
-		parentNode, ok := nameToNode[parentName]
+		parentNode, ok := pathToNode[pathKey{orgID: node.Folder.OrgId, path: parent}]
+		if !ok && len(pathToNodes[parent]) == 1 {
+			parentNode, ok = pathToNodes[parent][0], true
+		}
+
 		if ok {
 			parentNode.Children = append(parentNode.Children, node)
+			node.Parent = parentNode
+		}
+	}
+
+	for _, node := range nodes {
+		slices.SortStableFunc(node.Children, compareSiblingOrder)
+	}
+
+	return nodes, nil
+}
+
+// unlinks node from its parent, leaving it as the root of its own subtree.
+func detachNode(node *FolderNode) {
+	parent := node.Parent
+	if parent == nil {
+		return
+	}
+
+	for i, child := range parent.Children {
+		if child == node {
+			parent.Children = append(parent.Children[:i:i], parent.Children[i+1:]...)
+			break
+		}
+	}
+
+	node.Parent = nil
+	if isOrdered(parent.Children) {
+		numberChildren(parent)
+	}
+}
+
+// links node as the last child of parent.
+func attachNode(node *FolderNode, parent *FolderNode) {
+	ordered := isOrdered(parent.Children)
+
+	parent.Children = append(parent.Children, node)
+	node.Parent = parent
+
+	if ordered {
+		numberChildren(parent)
+	} else {
+		node.Folder.Position = 0
+	}
+}
+
+// links node as the child of parent at index, giving all of parent's
+// children explicit positions.
+func insertNode(node *FolderNode, parent *FolderNode, index int) {
+	parent.Children = slices.Insert(slices.Clip(parent.Children), index, node)
+	node.Parent = parent
+
+	numberChildren(parent)
+}
+
+// reports whether any of the siblings has been given a position.
+func isOrdered(siblings []*FolderNode) bool {
+	return slices.ContainsFunc(siblings, func(n *FolderNode) bool {
+		return n.Folder.Position != 0
+	})
+}
+
+// sets every child's Position to its place under parent, counting from 1.
+func numberChildren(parent *FolderNode) {
+	for i, child := range parent.Children {
+		child.Folder.Position = i + 1
+	}
+}
+
+// orders siblings by Position, where unpositioned ones go last.
+func compareSiblingOrder(a *FolderNode, b *FolderNode) int {
+	return cmp.Compare(siblingRank(a), siblingRank(b))
+}
+
+func siblingRank(node *FolderNode) int {
+	if node.Folder.Position == 0 {
+		return math.MaxInt
+	}
+
+	return node.Folder.Position
+}
+
+// rewrites the paths of node and all its descendants so that node lives at newPath.
+func rebasePaths(node *FolderNode, newPath string) {
+	oldPath := node.Folder.Paths
+	walkSubtree(node, func(n *FolderNode) {
+		n.Folder.Paths = replacePathPrefix(n.Folder.Paths, oldPath, newPath)
+	})
+}
+
+// calls fn on node and every node below it, parents before children.
+func walkSubtree(node *FolderNode, fn func(*FolderNode)) {
+	fn(node)
+	for _, child := range node.Children {
+		walkSubtree(child, fn)
+	}
+}
+
+// swaps the oldPrefix segments of path for newPrefix.
+// assumes path is oldPrefix itself or one of its descendants.
+func replacePathPrefix(path string, oldPrefix string, newPrefix string) string {
+	if path == oldPrefix {
+		return newPrefix
+	}
+
+	rest := path[len(oldPrefix)+1:]
+	if newPrefix == "" {
+		return rest
+	}
+
+	return newPrefix + "." + rest
+}
+
+// returns the path of path's parent, or "" for a root path.
+func parentPath(path string) string {
+	lastDot := strings.LastIndex(path, ".")
+	if lastDot < 0 {
+		return ""
+	}
+
+	return path[:lastDot]
+}
+
+// joins a parent path and a child name, where an empty parent path is the root.
+func childPath(parentPath string, name string) string {
+	if parentPath == "" {
+		return name
+	}
+
+	return parentPath + "." + name
+}
+
+// finds the only folder called name.
+func (d *driver) lookup(name string) (*FolderNode, error) {
+	nodes := d.nameToNodes[name]
+
+	switch len(nodes) {
+	case 0:
+		return nil, ErrFolderDoesNotExist
+	case 1:
+		return nodes[0], nil
+	}
+
+	return nil, ErrAmbiguousFolderName
+}
+
+// finds the folder with the given ID.
+func (d *driver) lookupID(id uuid.UUID) (*FolderNode, error) {
+	node, ok := d.idToNode[id]
+	if !ok {
+		return nil, ErrFolderDoesNotExist
+	}
+
+	return node, nil
+}
+
+// finds the only folder called name in orgID.
+func (d *driver) lookupInOrg(orgID uuid.UUID, name string) (*FolderNode, error) {
+	nodes := d.nameToNodes[name]
+	if len(nodes) == 0 {
+		return nil, ErrFolderDoesNotExist
+	}
+
+	var res *FolderNode
+	for _, node := range nodes {
+		if node.Folder.OrgId != orgID {
+			continue
+		}
+
+		if res != nil {
+			return nil, ErrAmbiguousFolderName
+		}
+
+		res = node
+	}
+
+	if res == nil {
+		return nil, ErrFolderDoesNotExistInOrg
+	}
+
+	return res, nil
+}
+
+// returns the folder of orgID at path, or nil if there is none.
+func (d *driver) folderAt(orgID uuid.UUID, path string) *FolderNode {
+	name := path[strings.LastIndex(path, ".")+1:]
+
+	for _, node := range d.nameToNodes[name] {
+		if node.Folder.OrgId == orgID && node.Folder.Paths == path {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// registers a new, unlinked node for folder with the driver.
+func (d *driver) addNode(folder Folder) *FolderNode {
+	node := &FolderNode{Folder: &folder}
+
+	d.nodes = append(d.nodes, node)
+	d.indexNode(node)
+
+	return node
+}
+
+// unregisters nodes from the driver. links between nodes are left as is.
+func (d *driver) removeNodes(nodes []*FolderNode) {
+	removed := map[*FolderNode]bool{}
+	for _, node := range nodes {
+		removed[node] = true
+		d.unindexNode(node)
+	}
+
+	d.nodes = slices.DeleteFunc(d.nodes, func(node *FolderNode) bool {
+		return removed[node]
+	})
+}
+
+func (d *driver) indexNode(node *FolderNode) {
+	d.searchIndex.add(node.Folder.OrgId, node.Folder.Name)
+	d.version++
+
+	name := node.Folder.Name
+	d.nameToNodes[name] = append(d.nameToNodes[name], node)
+
+	if node.Folder.ID != uuid.Nil {
+		d.idToNode[node.Folder.ID] = node
+	}
+}
+
+func (d *driver) unindexNode(node *FolderNode) {
+	d.searchIndex.remove(node.Folder.OrgId, node.Folder.Name)
+	d.version++
+
+	name := node.Folder.Name
+	d.nameToNodes[name] = slices.DeleteFunc(d.nameToNodes[name], func(n *FolderNode) bool {
+		return n == node
+	})
+
+	if len(d.nameToNodes[name]) == 0 {
+		delete(d.nameToNodes, name)
+	}
+
+	delete(d.idToNode, node.Folder.ID)
+}
+
+// returns a deep copy of the driver, so changes to the copy's tree leave d untouched.
+func (d *driver) clone() *driver {
+	c := newDriver()
+	c.nodes = make([]*FolderNode, len(d.nodes))
+	c.stateful = d.stateful
+	c.strict = d.strict
+
+	copies := map[*FolderNode]*FolderNode{nil: nil}
+	for i, node := range d.nodes {
+		folder := *node.Folder
+		c.nodes[i] = &FolderNode{Folder: &folder}
+		copies[node] = c.nodes[i]
+	}
+
+	for i, node := range d.nodes {
+		copied := c.nodes[i]
+		copied.Parent = copies[node.Parent]
+		for _, child := range node.Children {
+			copied.Children = append(copied.Children, copies[child])
 		}
 	}
 
-	return nameToNode, nil
+	for name, nodes := range d.nameToNodes {
+		for _, node := range nodes {
+			c.nameToNodes[name] = append(c.nameToNodes[name], copies[node])
+			c.searchIndex.add(node.Folder.OrgId, name)
+		}
+	}
+
+	for id, node := range d.idToNode {
+		c.idToNode[id] = copies[node]
+	}
+
+	return c
+}
+
+// replaces d's tree with c's, which must not be used afterwards.
+func (d *driver) adopt(c *driver) {
+	d.nodes = c.nodes
+	d.nameToNodes = c.nameToNodes
+	d.idToNode = c.idToNode
+	d.searchIndex = c.searchIndex
+	d.version++
 }
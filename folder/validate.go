@@ -0,0 +1,156 @@
+package folder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// IssueKind is a kind of problem Validate can find in a set of folders.
+type IssueKind int
+
+const (
+	// IssueOrphan is a folder whose parent path doesn't match any folder.
+	IssueOrphan IssueKind = iota
+	// IssueNameMismatch is a folder whose last path segment isn't its name.
+	IssueNameMismatch
+	// IssueDuplicateName is a folder sharing its name with a sibling,
+	// i.e. another folder of the same org has the same path.
+	IssueDuplicateName
+	// IssueDuplicateID is a folder sharing its ID with an earlier folder.
+	IssueDuplicateID
+	// IssueCrossOrgParent is a folder whose parent only exists in another org.
+	IssueCrossOrgParent
+	// IssueEmptySegment is a folder with an empty name or path segment.
+	IssueEmptySegment
+	// IssueIllegalCharacters is a folder whose name or path has characters
+	// that aren't allowed in an ltree label.
+	IssueIllegalCharacters
+)
+
+func (k IssueKind) String() string {
+	switch k {
+	case IssueOrphan:
+		return "parent folder doesn't exist"
+	case IssueNameMismatch:
+		return "last path segment doesn't match the folder name"
+	case IssueDuplicateName:
+		return "name is already used by a sibling"
+	case IssueDuplicateID:
+		return "ID is already used by another folder"
+	case IssueCrossOrgParent:
+		return "parent folder belongs to a different organization"
+	case IssueEmptySegment:
+		return "empty name or path segment"
+	case IssueIllegalCharacters:
+		return "illegal characters in name or path"
+	}
+
+	return fmt.Sprintf("IssueKind(%d)", int(k))
+}
+
+// Issue is a single problem found by Validate.
+type Issue struct {
+	Kind IssueKind
+	// position of the folder in the validated slice
+	Index  int
+	Folder Folder
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("folder %d (%q): %s", i.Index, i.Folder.Paths, i.Kind)
+}
+
+// ValidationReport lists every issue found by Validate, in folder order.
+type ValidationReport struct {
+	Issues []Issue
+}
+
+// OK reports whether no issues were found.
+func (r ValidationReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// Validate checks folders for everything BuildFolderTree would otherwise
+// skip over or link in surprising ways.
+func Validate(folders []Folder) ValidationReport {
+	type pathKey struct {
+		orgID uuid.UUID
+		path  string
+	}
+
+	report := ValidationReport{Issues: []Issue{}}
+	addIssue := func(kind IssueKind, i int) {
+		report.Issues = append(report.Issues, Issue{Kind: kind, Index: i, Folder: folders[i]})
+	}
+
+	paths := map[pathKey]bool{}
+	pathOrgs := map[string]bool{}
+	for _, f := range folders {
+		paths[pathKey{orgID: f.OrgId, path: f.Paths}] = true
+		pathOrgs[f.Paths] = true
+	}
+
+	seenPaths := map[pathKey]bool{}
+	seenIDs := map[uuid.UUID]bool{}
+
+	for i, f := range folders {
+		segments := strings.Split(f.Paths, ".")
+		wellFormed := true
+
+		if f.Name == "" || f.Paths == "" || strings.Contains(f.Paths, "..") ||
+			strings.HasPrefix(f.Paths, ".") || strings.HasSuffix(f.Paths, ".") {
+			addIssue(IssueEmptySegment, i)
+			wellFormed = false
+		}
+
+		if hasIllegalRunes(f.Name) || hasIllegalRunes(strings.ReplaceAll(f.Paths, ".", "")) {
+			addIssue(IssueIllegalCharacters, i)
+			wellFormed = false
+		}
+
+		if segments[len(segments)-1] != f.Name {
+			addIssue(IssueNameMismatch, i)
+		}
+
+		key := pathKey{orgID: f.OrgId, path: f.Paths}
+		if seenPaths[key] {
+			addIssue(IssueDuplicateName, i)
+		}
+		seenPaths[key] = true
+
+		if f.ID != uuid.Nil {
+			if seenIDs[f.ID] {
+				addIssue(IssueDuplicateID, i)
+			}
+			seenIDs[f.ID] = true
+		}
+
+		// parents of malformed paths can't be trusted.
+		parent := parentPath(f.Paths)
+		if !wellFormed || parent == "" {
+			continue
+		}
+
+		if !paths[pathKey{orgID: f.OrgId, path: parent}] {
+			if pathOrgs[parent] {
+				addIssue(IssueCrossOrgParent, i)
+			} else {
+				addIssue(IssueOrphan, i)
+			}
+		}
+	}
+
+	return report
+}
+
+func hasIllegalRunes(s string) bool {
+	for _, r := range s {
+		if !isLabelRune(r) {
+			return true
+		}
+	}
+
+	return false
+}
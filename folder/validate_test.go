@@ -0,0 +1,166 @@
+package folder_test
+
+import (
+	"testing"
+
+	"github.com/georgechieng-sc/interns-2022/folder"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_folder_Validate(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+	orgId2 := uuid.Must(uuid.NewV4())
+	id := uuid.Must(uuid.NewV4())
+
+	tests := [...]struct {
+		testName string
+		folders  []folder.Folder
+		expect   []folder.IssueKind
+	}{
+
+		//-------- valid datasets
+
+		{
+			testName: "Empty folders.",
+			folders:  []folder.Folder{},
+			expect:   []folder.IssueKind{},
+		},
+
+		{
+			testName: "Valid tree, names repeated under different parents.",
+			folders: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "reports", OrgId: orgId1, Paths: "alpha.reports"},
+				{Name: "bravo_2", OrgId: orgId1, Paths: "bravo_2"},
+				{Name: "reports", OrgId: orgId1, Paths: "bravo_2.reports"},
+				{Name: "alpha", OrgId: orgId2, Paths: "alpha"},
+			},
+			expect: []folder.IssueKind{},
+		},
+
+		//-------- invalid datasets
+
+		{
+			testName: "Orphan.",
+			folders: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+			},
+			expect: []folder.IssueKind{folder.IssueOrphan},
+		},
+
+		{
+			testName: "Name doesnt match path.",
+			folders: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "alpha.charlie"},
+			},
+			expect: []folder.IssueKind{folder.IssueNameMismatch},
+		},
+
+		{
+			testName: "Duplicate sibling names.",
+			folders: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+			},
+			expect: []folder.IssueKind{folder.IssueDuplicateName},
+		},
+
+		{
+			testName: "Duplicate IDs.",
+			folders: []folder.Folder{
+				{ID: id, Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{ID: id, Name: "bravo", OrgId: orgId1, Paths: "bravo"},
+			},
+			expect: []folder.IssueKind{folder.IssueDuplicateID},
+		},
+
+		{
+			testName: "Parent in another org.",
+			folders: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgId2, Paths: "alpha.bravo"},
+			},
+			expect: []folder.IssueKind{folder.IssueCrossOrgParent},
+		},
+
+		{
+			testName: "Empty segments.",
+			folders: []folder.Folder{
+				{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "alpha..bravo"},
+				{Name: "charlie", OrgId: orgId1, Paths: ".charlie"},
+				{Name: "", OrgId: orgId1, Paths: "alpha."},
+			},
+			expect: []folder.IssueKind{
+				folder.IssueEmptySegment,
+				folder.IssueEmptySegment,
+				folder.IssueEmptySegment,
+			},
+		},
+
+		{
+			testName: "Illegal characters.",
+			folders: []folder.Folder{
+				{Name: "al pha", OrgId: orgId1, Paths: "al pha"},
+				{Name: "bravo", OrgId: orgId1, Paths: "al pha.bravo"},
+			},
+			expect: []folder.IssueKind{
+				folder.IssueIllegalCharacters,
+				folder.IssueIllegalCharacters,
+			},
+		},
+
+		{
+			testName: "Several issues on one folder.",
+			folders: []folder.Folder{
+				{Name: "bravo", OrgId: orgId1, Paths: "alpha.charlie"},
+			},
+			expect: []folder.IssueKind{folder.IssueNameMismatch, folder.IssueOrphan},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			report := folder.Validate(tc.folders)
+
+			kinds := []folder.IssueKind{}
+			for _, issue := range report.Issues {
+				kinds = append(kinds, issue.Kind)
+				assert.Equal(t, tc.folders[issue.Index], issue.Folder, "issue points at the wrong folder")
+			}
+
+			assert.Equal(t, tc.expect, kinds, "unexpected issues")
+			assert.Equal(t, len(tc.expect) == 0, report.OK(), "unexpected OK")
+		})
+	}
+}
+
+func Test_folder_NewDriver_WithValidation(t *testing.T) {
+	t.Parallel()
+
+	orgId1 := uuid.FromStringOrNil(folder.DefaultOrgID)
+
+	folders := []folder.Folder{
+		{Name: "alpha", OrgId: orgId1, Paths: "alpha"},
+		{Name: "charlie", OrgId: orgId1, Paths: "alpha.bravo.charlie"},
+	}
+
+	// by default the orphan is quietly left out of the tree.
+	_, err := folder.NewDriver(folders)
+	assert.NoError(t, err, "unexpected error")
+
+	_, err = folder.NewDriver(folders, folder.WithValidation())
+	assert.ErrorIs(t, err, folder.ErrInvalidDataset)
+
+	_, err = folder.NewDriver(folder.GetSampleData(), folder.WithValidation())
+	assert.NoError(t, err, "sample data should be valid")
+}